@@ -1,14 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"gateway/internal/autoscale"
 	"gateway/internal/config"
 
 	grpcServer "gateway/internal/grpc"
+	"gateway/internal/job"
 	"gateway/internal/k8s"
+	"gateway/internal/local"
+	"gateway/internal/orchestrator"
+	"gateway/internal/refresh"
+	"gateway/internal/registry"
+	"gateway/internal/reshare"
 	"gateway/internal/server"
+	"gateway/internal/session"
+	"gateway/internal/static"
+	"gateway/internal/store"
 )
 
 func main() {
@@ -19,34 +31,94 @@ func main() {
 	cfg := config.Get()
 	log.Printf("Starting server on port: %d", cfg.Server.Port)
 
+	// 재시작 후에도 키와 작업을 찾을 수 있도록 저장소에서 불러옵니다.
+	db, err := openStore()
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+	defer db.Close()
+	keys, err := registry.New(db)
+	if err != nil {
+		log.Fatalf("Failed to load key registry: %v", err)
+	}
+	jobs, err := job.NewStore(db, time.Duration(cfg.Job.RetentionHours)*time.Hour)
+	if err != nil {
+		log.Fatalf("Failed to load jobs: %v", err)
+	}
+
 	// gRPC 서버 생성
-	keygenServer := grpcServer.NewKeygenServiceServer()
+	// 닫히지 않은 세션은 타임아웃의 두 배가 지나면 정리합니다.
+	sessionTimeout := time.Duration(cfg.Session.TimeoutSeconds) * time.Second
+	sessions, err := session.NewRegistry(2*sessionTimeout, db)
+	if err != nil {
+		log.Fatalf("Failed to load sessions: %v", err)
+	}
+	keygenServer := grpcServer.NewKeygenServiceServer(sessions)
+	relayServer := grpcServer.NewRelayServer()
 
 	// gRPC 서버 실행 (별도의 고루틴에서)
-	go grpcServer.StartGRPCServer(keygenServer)
+	go grpcServer.StartGRPCServer(keygenServer, relayServer)
 
-	// 서버 시작 시 기존 Pod 확인 및 대기 풀에 추가
-	existingPods, err := k8s.ListExistingPods()
+	// 서버 시작 시 기존 파티를 대기 풀에 넣고, 필요한 경우 새로운 파티 생성
+	orch, err := openOrchestrator()
 	if err != nil {
-		log.Fatalf("Failed to list existing pods: %v", err)
+		log.Fatalf("Failed to create orchestrator: %v", err)
 	}
-	fmt.Println("existingPods: ", existingPods)
-	podPool := k8s.GetPodPool()
-	for _, pod := range existingPods {
-		podPool.AddPod(pod)
+	// 키 조각을 보관한 파티는 재시작 후에도 새 키 생성에 빌려주지 않도록 먼저 배정합니다.
+	for _, key := range keys.List(nil) {
+		for _, party := range append(append([]registry.Party{}, key.Parties...), key.Retired...) {
+			orch.Assign(key.ID, party.Name)
+		}
+	}
+	if err := orch.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start orchestrator: %v", err)
 	}
-	log.Printf("Added %d existing pods to the pool", len(existingPods))
 
-	// 필요한 경우 새로운 Pod 생성
-	initialPodCount := cfg.Kubernetes.InitialPodCount
-	fmt.Println("initialPodCount", initialPodCount)
-	if len(existingPods) < initialPodCount {
-		if err := k8s.CreatePods(initialPodCount - len(existingPods)); err != nil {
-			log.Fatalf("Failed to create initial pods: %v", err)
-		}
+	var scaler *autoscale.Scaler
+	if cfg.Autoscale.Enabled {
+		scaler = autoscale.NewScaler(orch, cfg.Autoscale.MinIdle, cfg.Autoscale.MaxWorkers,
+			time.Duration(cfg.Autoscale.IntervalSeconds)*time.Second, time.Duration(cfg.Autoscale.CooldownSeconds)*time.Second)
+		scaler.Start(context.Background())
+		log.Printf("Autoscaling parties with %d idle, up to %d in total", cfg.Autoscale.MinIdle, cfg.Autoscale.MaxWorkers)
+	}
+
+	reshares := reshare.NewCoordinator(keys, relayServer, orch)
+	// 재공유로 위원회에서 빠진 파티의 이전 세대 조각은 지울 때까지 주기적으로 다시 지웁니다.
+	reshares.Start(context.Background())
+
+	// 키 조각 주기적 갱신
+	if cfg.Refresh.IntervalHours > 0 {
+		interval := time.Duration(cfg.Refresh.IntervalHours) * time.Hour
+		refresh.NewScheduler(keys, reshares, interval).Start(context.Background())
+		log.Printf("Refreshing key shares every %s", interval)
 	}
 
 	// HTTP 서버에 keygenServer 전달
-	srv := server.NewServer(keygenServer)
+	srv := server.NewServer(keygenServer, relayServer, keys, jobs, reshares, orch, scaler)
 	srv.Run(fmt.Sprintf(":%d", cfg.Server.Port))
 }
+
+// openStore는 설정된 게이트웨이 상태 저장소를 엽니다.
+func openStore() (store.Store, error) {
+	cfg := config.Get()
+	if cfg.Storage.Backend == config.StorageMemory {
+		log.Printf("Using in-memory storage; keys and jobs are lost on restart")
+		return store.NewMemory(), nil
+	}
+	log.Printf("Using SQLite storage at %s", cfg.Storage.Path)
+	return store.OpenSQLite(cfg.Storage.Path)
+}
+
+// openOrchestrator는 설정된 방식으로 파티 워커를 관리하는 오케스트레이터를 만듭니다.
+func openOrchestrator() (orchestrator.Orchestrator, error) {
+	cfg := config.Get()
+	switch cfg.Orchestrator.Backend {
+	case config.OrchestratorLocal:
+		log.Printf("Running parties as local processes from %s", cfg.Orchestrator.Local.Binary)
+		return local.New(), nil
+	case config.OrchestratorStatic:
+		log.Printf("Using static roster of %d parties", len(cfg.Orchestrator.Static.Parties))
+		return static.New()
+	}
+	return k8s.New()
+}
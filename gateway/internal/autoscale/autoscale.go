@@ -0,0 +1,199 @@
+package autoscale
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gateway/internal/orchestrator"
+)
+
+// startupGrace는 만든 워커가 SERVING을 보고해 풀에 들어올 때까지 기다리는 시간입니다.
+// 그동안은 아직 풀에 없는 워커도 만들어진 것으로 세어 같은 수요로 워커를 또 만들지 않습니다.
+const startupGrace = 10 * time.Minute
+
+// Status는 오토스케일러의 목표와 실제 워커 수입니다. 오토스케일러가 꺼져 있으면 Actual만 채웁니다.
+type Status struct {
+	Enabled     bool `json:"enabled"`
+	MinIdle     int  `json:"min_idle"`
+	MaxWorkers  int  `json:"max_workers"`
+	TargetIdle  int  `json:"target_idle"`
+	TargetTotal int  `json:"target_total"`
+	// Starting은 만들고 있거나, 만들었지만 아직 SERVING을 보고하지 않은 워커 수입니다.
+	Starting      int                `json:"starting"`
+	Actual        orchestrator.Stats `json:"actual"`
+	LastScaleUp   *time.Time         `json:"last_scale_up,omitempty"`
+	LastScaleDown *time.Time         `json:"last_scale_down,omitempty"`
+}
+
+// Scaler는 풀의 쉬고 있는 워커 수를 수요에 맞춥니다.
+//
+// 평소에는 쉬고 있는 워커를 minIdle개 유지하고, 대기열에서 워커를 기다리는 요청이 있으면 그만큼 더 만듭니다.
+// 쉬고 있는 워커가 목표보다 많은 상태가 cooldown 동안 이어지면 남는 워커를 지웁니다.
+// 세션이 빌려 간 워커와 키 조각을 보관한 워커는 지우지 않으며, 전체 워커 수는 maxWorkers를 넘지 않습니다.
+// 서명을 기다리는 요청은 키 조각을 보관한 파티만 받을 수 있어 새 워커로 해결되지 않으므로 수요로 세지 않습니다.
+type Scaler struct {
+	orch       orchestrator.Orchestrator
+	interval   time.Duration
+	cooldown   time.Duration
+	minIdle    int
+	maxWorkers int
+	// now는 현재 시각입니다. 테스트에서 바꿉니다.
+	now func() time.Time
+
+	mu       sync.Mutex
+	creating int
+	// started는 만들었지만 아직 풀에 없는 워커와 만든 시각입니다.
+	started       map[string]time.Time
+	targetIdle    int
+	targetTotal   int
+	surplusSince  time.Time
+	lastScaleUp   time.Time
+	lastScaleDown time.Time
+}
+
+func NewScaler(orch orchestrator.Orchestrator, minIdle, maxWorkers int, interval, cooldown time.Duration) *Scaler {
+	return &Scaler{
+		orch:       orch,
+		interval:   interval,
+		cooldown:   cooldown,
+		minIdle:    minIdle,
+		maxWorkers: maxWorkers,
+		now:        time.Now,
+		started:    make(map[string]time.Time),
+	}
+}
+
+// Start는 ctx가 끝날 때까지 백그라운드에서 interval마다 워커 수를 조정합니다.
+func (s *Scaler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.scale(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Status는 마지막으로 계산한 목표와 지금의 워커 수를 반환합니다.
+func (s *Scaler) Status() Status {
+	actual := s.orch.Stats()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := Status{
+		Enabled:     true,
+		MinIdle:     s.minIdle,
+		MaxWorkers:  s.maxWorkers,
+		TargetIdle:  s.targetIdle,
+		TargetTotal: s.targetTotal,
+		Starting:    s.creating + len(s.started),
+		Actual:      actual,
+	}
+	if up := s.lastScaleUp; !up.IsZero() {
+		status.LastScaleUp = &up
+	}
+	if down := s.lastScaleDown; !down.IsZero() {
+		status.LastScaleDown = &down
+	}
+	return status
+}
+
+func (s *Scaler) scale(ctx context.Context) {
+	if n := s.plan(); n < 0 {
+		s.scaleDown(ctx, -n)
+	} else if n > 0 {
+		go s.scaleUp(ctx, n)
+	}
+}
+
+// plan은 목표 워커 수를 다시 계산하고 만들 워커 수(양수) 또는 지울 워커 수(음수)를 반환합니다.
+func (s *Scaler) plan() int {
+	stats := s.orch.Stats()
+	demand := s.orch.Demand()
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, at := range s.started {
+		if s.orch.Has(name) || now.Sub(at) > startupGrace {
+			delete(s.started, name)
+		}
+	}
+	starting := s.creating + len(s.started)
+
+	// 빌려준 워커와 키 조각을 보관한 워커는 그대로 두고, 쉬고 있는 워커 수만 목표에 맞춥니다.
+	s.targetIdle = s.minIdle + demand
+	s.targetTotal = stats.Total - stats.Idle + s.targetIdle
+	if s.targetTotal > s.maxWorkers {
+		s.targetTotal = s.maxWorkers
+	}
+
+	switch {
+	case stats.Total+starting < s.targetTotal:
+		n := s.targetTotal - stats.Total - starting
+		log.Printf("Scaling up by %d workers (idle %d, target idle %d, starting %d)", n, stats.Idle, s.targetIdle, starting)
+		s.creating += n
+		s.lastScaleUp = now
+		s.surplusSince = time.Time{}
+		return n
+
+	case stats.Idle > s.targetIdle && starting == 0:
+		if s.surplusSince.IsZero() {
+			s.surplusSince = now
+		}
+		if now.Sub(s.surplusSince) < s.cooldown || now.Sub(s.lastScaleUp) < s.cooldown {
+			return 0
+		}
+		n := stats.Idle - s.targetIdle
+		if over := stats.Total - s.maxWorkers; over > n {
+			n = over
+		}
+		log.Printf("Scaling down by %d idle workers (idle %d, target idle %d)", n, stats.Idle, s.targetIdle)
+		s.lastScaleDown = now
+		s.surplusSince = time.Time{}
+		return -n
+	}
+
+	s.surplusSince = time.Time{}
+	return 0
+}
+
+// scaleUp은 워커 n개를 만듭니다. 만든 워커는 SERVING을 보고해 풀에 들어올 때까지 시작 중으로 셉니다.
+func (s *Scaler) scaleUp(ctx context.Context, n int) {
+	workers, err := s.orch.Create(ctx, n)
+	if err != nil {
+		log.Printf("Failed to create workers: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creating -= n
+	now := s.now()
+	for _, worker := range workers {
+		s.started[worker.Name] = now
+	}
+}
+
+// scaleDown은 쉬고 있는 워커를 최대 n개 지웁니다. 지우는 동안 다른 세션이 빌려 가지 않도록 먼저 빌립니다.
+func (s *Scaler) scaleDown(ctx context.Context, n int) {
+	owner := "autoscaler-" + uuid.NewString()
+	defer s.orch.Release(owner)
+
+	for _, worker := range s.orch.LeaseIdle(owner, n) {
+		if err := s.orch.Delete(ctx, worker.Name); err != nil {
+			log.Printf("Failed to delete idle worker %s: %v", worker.Name, err)
+			continue
+		}
+		log.Printf("Deleted idle worker %s", worker.Name)
+	}
+}
@@ -0,0 +1,257 @@
+package autoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"gateway/internal/orchestrator"
+)
+
+// stubOrchestrator는 정해진 풀 상태를 보고하고, 만들고 빌리고 지운 워커를 기록합니다.
+type stubOrchestrator struct {
+	orchestrator.Orchestrator
+	stats  orchestrator.Stats
+	demand int
+	// live는 풀에 있는 워커, idle은 LeaseIdle이 빌려줄 워커입니다.
+	live map[string]bool
+	idle []string
+	// failDelete는 지우지 못하는 워커입니다.
+	failDelete string
+
+	created  int
+	leased   map[string]string
+	released []string
+	deleted  []string
+}
+
+func newStub(stats orchestrator.Stats, demand int) *stubOrchestrator {
+	return &stubOrchestrator{stats: stats, demand: demand, live: make(map[string]bool), leased: make(map[string]string)}
+}
+
+func (o *stubOrchestrator) Stats() orchestrator.Stats { return o.stats }
+
+func (o *stubOrchestrator) Demand() int { return o.demand }
+
+func (o *stubOrchestrator) Has(name string) bool { return o.live[name] }
+
+func (o *stubOrchestrator) Create(_ context.Context, n int) ([]orchestrator.Worker, error) {
+	workers := make([]orchestrator.Worker, n)
+	for i := range workers {
+		o.created++
+		workers[i] = orchestrator.Worker{Name: fmt.Sprintf("new-%d", o.created)}
+	}
+	return workers, nil
+}
+
+func (o *stubOrchestrator) LeaseIdle(owner string, n int) []orchestrator.Worker {
+	var workers []orchestrator.Worker
+	for _, name := range o.idle[:min(n, len(o.idle))] {
+		o.leased[name] = owner
+		workers = append(workers, orchestrator.Worker{Name: name})
+	}
+	return workers
+}
+
+func (o *stubOrchestrator) Release(owner string) {
+	for name, holder := range o.leased {
+		if holder == owner {
+			delete(o.leased, name)
+			o.released = append(o.released, name)
+		}
+	}
+}
+
+func (o *stubOrchestrator) Delete(_ context.Context, name string) error {
+	if name == o.failDelete {
+		return errors.New("delete failed")
+	}
+	o.deleted = append(o.deleted, name)
+	return nil
+}
+
+// clock은 테스트에서 움직이는 시계입니다.
+type clock struct{ now time.Time }
+
+func (c *clock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestScaler(orch *stubOrchestrator, minIdle, maxWorkers int, cooldown time.Duration) (*Scaler, *clock) {
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewScaler(orch, minIdle, maxWorkers, time.Minute, cooldown)
+	s.now = func() time.Time { return c.now }
+	return s, c
+}
+
+func TestPlanScaleUp(t *testing.T) {
+	tests := []struct {
+		name   string
+		stats  orchestrator.Stats
+		demand int
+		// want는 만들 워커 수, wantTotal은 목표 전체 워커 수입니다.
+		want      int
+		wantTotal int
+	}{
+		{"empty pool", orchestrator.Stats{}, 0, 2, 2},
+		{"enough idle workers", orchestrator.Stats{Total: 2, Idle: 2}, 0, 0, 2},
+		{"busy workers are kept", orchestrator.Stats{Total: 3, Idle: 1, Leased: 1, Assigned: 1}, 0, 1, 4},
+		{"queued demand", orchestrator.Stats{Total: 3, Idle: 0, Leased: 3}, 3, 5, 8},
+		{"clamped to maxWorkers", orchestrator.Stats{Total: 8, Idle: 0, Leased: 8}, 5, 2, 10},
+		{"at maxWorkers", orchestrator.Stats{Total: 10, Idle: 0, Leased: 10}, 5, 0, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestScaler(newStub(tt.stats, tt.demand), 2, 10, time.Minute)
+			if got := s.plan(); got != tt.want {
+				t.Fatalf("plan = %d, want %d", got, tt.want)
+			}
+			status := s.Status()
+			if status.TargetIdle != 2+tt.demand || status.TargetTotal != tt.wantTotal {
+				t.Fatalf("target idle %d total %d, want %d and %d", status.TargetIdle, status.TargetTotal, 2+tt.demand, tt.wantTotal)
+			}
+			if status.Starting != tt.want {
+				t.Fatalf("starting = %d, want %d", status.Starting, tt.want)
+			}
+		})
+	}
+}
+
+// 만든 워커는 풀에 들어오거나 startupGrace가 지날 때까지 시작 중으로 세어 같은 수요로 또 만들지 않습니다.
+func TestPlanCountsStartingWorkers(t *testing.T) {
+	orch := newStub(orchestrator.Stats{Total: 1, Leased: 1}, 0)
+	s, c := newTestScaler(orch, 2, 10, time.Minute)
+
+	n := s.plan()
+	if n != 2 {
+		t.Fatalf("plan = %d, want 2", n)
+	}
+	// 만드는 중인 워커도 셉니다.
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan while creating = %d, want 0", got)
+	}
+	s.scaleUp(context.Background(), n)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan while starting = %d, want 0", got)
+	}
+
+	// 한 워커가 풀에 들어오면 풀에서 셉니다.
+	orch.live["new-1"] = true
+	orch.stats = orchestrator.Stats{Total: 2, Idle: 1, Leased: 1}
+	if got := s.plan(); got != 0 || s.Status().Starting != 1 {
+		t.Fatalf("plan = %d with %d starting, want 0 with 1 starting", got, s.Status().Starting)
+	}
+
+	// startupGrace가 지나도록 풀에 들어오지 않은 워커는 더 세지 않고 다시 만듭니다.
+	c.advance(startupGrace - time.Second)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan before startupGrace = %d, want 0", got)
+	}
+	c.advance(2 * time.Second)
+	if got := s.plan(); got != 1 {
+		t.Fatalf("plan after startupGrace = %d, want 1", got)
+	}
+}
+
+// 쉬고 있는 워커가 남는 상태가 cooldown 동안 이어져야 남는 만큼 지웁니다.
+func TestPlanScaleDownCooldown(t *testing.T) {
+	orch := newStub(orchestrator.Stats{Total: 5, Idle: 5}, 0)
+	s, c := newTestScaler(orch, 2, 10, 5*time.Minute)
+
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan on the first surplus = %d, want 0", got)
+	}
+	c.advance(4 * time.Minute)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan before cooldown = %d, want 0", got)
+	}
+
+	// 남는 상태가 끊기면 cooldown을 처음부터 다시 잽니다.
+	orch.stats = orchestrator.Stats{Total: 5, Idle: 2, Leased: 3}
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan without surplus = %d, want 0", got)
+	}
+	orch.stats = orchestrator.Stats{Total: 5, Idle: 5}
+	c.advance(time.Minute)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan on a new surplus = %d, want 0", got)
+	}
+	c.advance(5 * time.Minute)
+	if got := s.plan(); got != -3 {
+		t.Fatalf("plan after cooldown = %d, want -3", got)
+	}
+	if s.Status().LastScaleDown == nil {
+		t.Fatal("last scale down was not recorded")
+	}
+}
+
+// 최근에 워커를 만들었으면 cooldown이 지날 때까지 지우지 않습니다.
+func TestPlanNoScaleDownAfterScaleUp(t *testing.T) {
+	orch := newStub(orchestrator.Stats{Total: 2, Leased: 2}, 3)
+	s, c := newTestScaler(orch, 1, 10, 5*time.Minute)
+	if n := s.plan(); n != 4 {
+		t.Fatalf("plan = %d, want 4", n)
+	}
+	s.scaleUp(context.Background(), 4)
+	for i := 1; i <= 4; i++ {
+		orch.live[fmt.Sprintf("new-%d", i)] = true
+	}
+
+	// 기다리던 요청이 사라져 쉬고 있는 워커가 남습니다.
+	orch.stats = orchestrator.Stats{Total: 6, Idle: 4, Leased: 2}
+	orch.demand = 0
+	c.advance(time.Minute)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan right after a scale up = %d, want 0", got)
+	}
+	c.advance(4 * time.Minute)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan before cooldown = %d, want 0", got)
+	}
+	c.advance(time.Minute)
+	if got := s.plan(); got != -3 {
+		t.Fatalf("plan after cooldown = %d, want -3", got)
+	}
+}
+
+// 전체 워커 수가 maxWorkers를 넘으면 남는 쉬고 있는 워커보다 많이 지웁니다.
+func TestPlanScaleDownToMaxWorkers(t *testing.T) {
+	orch := newStub(orchestrator.Stats{Total: 8, Idle: 4, Leased: 4}, 0)
+	s, c := newTestScaler(orch, 2, 4, time.Minute)
+	if got := s.plan(); got != 0 {
+		t.Fatalf("plan on the first surplus = %d, want 0", got)
+	}
+	c.advance(2 * time.Minute)
+	if got := s.plan(); got != -4 {
+		t.Fatalf("plan = %d, want -4", got)
+	}
+}
+
+// scaleDown은 쉬고 있는 워커만 빌려서 지우고, 지우지 못한 워커까지 모두 돌려줍니다.
+func TestScaleDown(t *testing.T) {
+	orch := newStub(orchestrator.Stats{}, 0)
+	orch.idle = []string{"a", "b", "c", "d"}
+	orch.failDelete = "b"
+	s, _ := newTestScaler(orch, 0, 10, time.Minute)
+
+	s.scaleDown(context.Background(), 3)
+	if want := []string{"a", "c"}; !slices.Equal(orch.deleted, want) {
+		t.Fatalf("deleted %v, want %v", orch.deleted, want)
+	}
+	slices.Sort(orch.released)
+	if want := []string{"a", "b", "c"}; !slices.Equal(orch.released, want) {
+		t.Fatalf("released %v, want %v", orch.released, want)
+	}
+	if len(orch.leased) != 0 {
+		t.Fatalf("workers still leased after scaleDown: %v", orch.leased)
+	}
+
+	// 쉬고 있는 워커보다 많이 지우라고 해도 있는 만큼만 지웁니다.
+	orch.deleted = nil
+	orch.failDelete = ""
+	s.scaleDown(context.Background(), 10)
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(orch.deleted, want) {
+		t.Fatalf("deleted %v, want %v", orch.deleted, want)
+	}
+}
@@ -7,16 +7,133 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// 파티 사이의 라운드 메시지 전달 방식
+const (
+	// RoutingDirect는 파티끼리 직접 gRPC로 메시지를 주고받습니다.
+	RoutingDirect = "direct"
+	// RoutingRelay는 모든 메시지를 게이트웨이를 거쳐 주고받습니다.
+	// NetworkPolicy로 Pod 간 통신이 막힌 클러스터에서 사용합니다.
+	RoutingRelay = "relay"
+)
+
+// 파티 워커를 실행하는 오케스트레이터
+const (
+	// OrchestratorKubernetes는 파티를 Kubernetes Pod으로 실행합니다.
+	OrchestratorKubernetes = "kubernetes"
+	// OrchestratorLocal은 party 바이너리를 로컬 프로세스로 실행합니다. 클러스터 없이 개발하거나 CI에서 사용합니다.
+	OrchestratorLocal = "local"
+	// OrchestratorStatic은 config.yaml에 나열된 파티(전용 호스트 등)를 사용합니다. 파티를 만들거나 지우지 않습니다.
+	OrchestratorStatic = "static"
+)
+
+// StaticParty는 정적 명단의 파티 하나입니다. Name은 파티가 자신의 이름(party.name)으로 사용하는 값입니다.
+// TLS가 있으면 게이트웨이는 TLS로 접속합니다.
+type StaticParty struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Port    int32  `yaml:"port"`
+	TLS     *struct {
+		// ServerName은 파티 인증서에 있어야 하는 이름입니다. 비어 있으면 address를 사용합니다.
+		ServerName string `yaml:"serverName"`
+		CAFile     string `yaml:"caFile"`
+		// CertFile, KeyFile은 파티가 클라이언트 인증서를 요구할 때 게이트웨이가 제시할 인증서입니다.
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+	} `yaml:"tls"`
+}
+
+// 게이트웨이 상태 저장소
+const (
+	// StorageSQLite는 키, 작업, 세션 기록을 SQLite 파일에 저장합니다.
+	StorageSQLite = "sqlite"
+	// StorageMemory는 메모리에만 저장합니다. 재시작하면 모든 상태가 사라집니다.
+	StorageMemory = "memory"
+)
+
 type Config struct {
 	Kubernetes struct {
 		Namespace       string `yaml:"namespace"`
 		PodPrefix       string `yaml:"podPrefix"`
 		PoImage         string `yaml:"podImage"`
 		InitialPodCount int    `yaml:"initialPodCount"`
+		// ShareKEKSecret은 파티의 키 조각 암호화 키를 kek 항목으로 담은 Secret입니다. 파티 Pod에 PARTY_SHARE_KEK로 전달합니다.
+		ShareKEKSecret string `yaml:"shareKEKSecret"`
+		// PartyServiceAccount는 파티 Pod의 서비스 계정입니다. 파티가 키 조각을 Secret에 저장하므로 secrets 권한이 필요합니다.
+		PartyServiceAccount string `yaml:"partyServiceAccount"`
+		// GatewayHost는 파티 Pod이 게이트웨이 gRPC 서버(grpc.port)에 접속할 주소(Service 이름)입니다.
+		// 세션은 게이트웨이 프로세스에만 있으므로 Service 뒤의 게이트웨이는 복제본 하나여야 합니다.
+		GatewayHost string `yaml:"gatewayHost"`
 	} `yaml:"kubernetes"`
 	Server struct {
 		Port int `yaml:"port"`
 	} `yaml:"server"`
+	GRPC struct {
+		Port int `yaml:"port"`
+		// TLS가 있으면 파티가 접속하는 gRPC 서버(중계, 완료와 진행 보고)를 TLS로 엽니다.
+		// 전용 호스트의 파티(orchestrator.static)가 네트워크를 건너 접속할 때 사용하며, 파티에는 gateway.tls를 설정해야 합니다.
+		TLS *struct {
+			CertFile string `yaml:"certFile"`
+			KeyFile  string `yaml:"keyFile"`
+			// ClientCAFile이 있으면 이 CA가 발급한 클라이언트 인증서(파티)만 받습니다.
+			ClientCAFile string `yaml:"clientCAFile"`
+		} `yaml:"tls"`
+	} `yaml:"grpc"`
+	Orchestrator struct {
+		Backend string `yaml:"backend"`
+		// HealthCheckSeconds는 파티에 헬스 체크를 보내는 주기(초)입니다. SERVING을 보고한 파티만 사용합니다.
+		HealthCheckSeconds int `yaml:"healthCheckSeconds"`
+
+		Local struct {
+			// Binary는 빌드한 party 바이너리 경로입니다.
+			Binary     string `yaml:"binary"`
+			WorkDir    string `yaml:"workDir"`
+			NamePrefix string `yaml:"namePrefix"`
+			// BasePort부터 워커마다 하나씩 gRPC 포트를 사용합니다.
+			BasePort          int `yaml:"basePort"`
+			Workers           int `yaml:"workers"`
+			PreParamsPoolSize int `yaml:"preParamsPoolSize"`
+		} `yaml:"local"`
+		Static struct {
+			Parties []StaticParty `yaml:"parties"`
+		} `yaml:"static"`
+	} `yaml:"orchestrator"`
+	Routing struct {
+		Mode string `yaml:"mode"`
+	} `yaml:"routing"`
+	Session struct {
+		TimeoutSeconds int `yaml:"timeoutSeconds"`
+	} `yaml:"session"`
+	Autoscale struct {
+		Enabled bool `yaml:"enabled"`
+		// MinIdle은 새 키 생성에 바로 쓸 수 있도록 유지할 쉬고 있는 파티 수입니다.
+		MinIdle int `yaml:"minIdle"`
+		// MaxWorkers는 오토스케일러가 유지하는 전체 파티 수의 상한입니다.
+		MaxWorkers      int `yaml:"maxWorkers"`
+		IntervalSeconds int `yaml:"intervalSeconds"`
+		// CooldownSeconds 동안 남는 파티가 이어지고 그동안 늘린 적이 없어야 줄입니다.
+		CooldownSeconds int `yaml:"cooldownSeconds"`
+	} `yaml:"autoscale"`
+	Pool struct {
+		// LeaseTimeoutSeconds가 지나도록 돌려받지 못한 워커는 세션이 비정상 종료된 것으로 보고 풀로 돌려받습니다.
+		LeaseTimeoutSeconds int `yaml:"leaseTimeoutSeconds"`
+		// QueueTimeoutSeconds는 파티가 부족할 때 요청이 대기열에서 파티를 기다리는 최대 시간(초)입니다.
+		QueueTimeoutSeconds int `yaml:"queueTimeoutSeconds"`
+		// MaxQueueLength는 대기열에서 기다릴 수 있는 요청 수입니다. 0이면 기다리지 않고 바로 실패합니다.
+		MaxQueueLength int `yaml:"maxQueueLength"`
+	} `yaml:"pool"`
+	Refresh struct {
+		// IntervalHours는 키 조각을 새로 고치는 주기(시간)입니다. 0이면 갱신하지 않습니다.
+		IntervalHours int `yaml:"intervalHours"`
+	} `yaml:"refresh"`
+	Job struct {
+		// RetentionHours는 끝난 비동기 작업을 조회할 수 있도록 남겨 두는 시간(시간)입니다. 지난 작업은 메모리와 저장소에서 지웁니다.
+		RetentionHours int `yaml:"retentionHours"`
+	} `yaml:"job"`
+	Storage struct {
+		Backend string `yaml:"backend"`
+		// Path는 SQLite 데이터베이스 파일 경로입니다.
+		Path string `yaml:"path"`
+	} `yaml:"storage"`
 }
 
 var cfg Config
@@ -34,9 +151,151 @@ func Load() error {
 		return fmt.Errorf("error decoding config file: %v", err)
 	}
 
+	switch cfg.Routing.Mode {
+	case "":
+		cfg.Routing.Mode = RoutingDirect
+	case RoutingDirect, RoutingRelay:
+	default:
+		return fmt.Errorf("unknown routing mode: %s", cfg.Routing.Mode)
+	}
+
+	if cfg.Kubernetes.ShareKEKSecret == "" {
+		cfg.Kubernetes.ShareKEKSecret = "tss-share-kek"
+	}
+	if cfg.Kubernetes.PartyServiceAccount == "" {
+		cfg.Kubernetes.PartyServiceAccount = "tss-party"
+	}
+	if cfg.Kubernetes.GatewayHost == "" {
+		cfg.Kubernetes.GatewayHost = "tss-gateway"
+	}
+
+	if cfg.GRPC.Port == 0 {
+		cfg.GRPC.Port = 50051
+	}
+
+	switch cfg.Orchestrator.Backend {
+	case "":
+		cfg.Orchestrator.Backend = OrchestratorKubernetes
+	case OrchestratorKubernetes, OrchestratorLocal, OrchestratorStatic:
+	default:
+		return fmt.Errorf("unknown orchestrator backend: %s", cfg.Orchestrator.Backend)
+	}
+	if cfg.Orchestrator.HealthCheckSeconds <= 0 {
+		cfg.Orchestrator.HealthCheckSeconds = 10
+	}
+	if err := loadStatic(); err != nil {
+		return err
+	}
+	// Kubernetes와 로컬 파티는 게이트웨이에 평문으로 접속합니다.
+	if cfg.GRPC.TLS != nil && cfg.Orchestrator.Backend != OrchestratorStatic {
+		return fmt.Errorf("grpc.tls requires orchestrator backend %s", OrchestratorStatic)
+	}
+	local := &cfg.Orchestrator.Local
+	if local.Binary == "" {
+		local.Binary = "../party/party"
+	}
+	if local.WorkDir == "" {
+		local.WorkDir = "data/parties"
+	}
+	if local.NamePrefix == "" {
+		local.NamePrefix = "local-party"
+	}
+	if local.BasePort == 0 {
+		local.BasePort = 50061
+	}
+	if local.PreParamsPoolSize <= 0 {
+		local.PreParamsPoolSize = 1
+	}
+
+	if cfg.Session.TimeoutSeconds <= 0 {
+		cfg.Session.TimeoutSeconds = 300
+	}
+	if cfg.Pool.LeaseTimeoutSeconds <= 0 {
+		cfg.Pool.LeaseTimeoutSeconds = 900
+	}
+	if cfg.Pool.QueueTimeoutSeconds <= 0 {
+		cfg.Pool.QueueTimeoutSeconds = 60
+	}
+	if cfg.Job.RetentionHours <= 0 {
+		cfg.Job.RetentionHours = 168
+	}
+	if cfg.Pool.MaxQueueLength < 0 {
+		return fmt.Errorf("pool.maxQueueLength must not be negative: %d", cfg.Pool.MaxQueueLength)
+	}
+	if err := loadAutoscale(); err != nil {
+		return err
+	}
+
+	switch cfg.Storage.Backend {
+	case "":
+		cfg.Storage.Backend = StorageSQLite
+	case StorageSQLite, StorageMemory:
+	default:
+		return fmt.Errorf("unknown storage backend: %s", cfg.Storage.Backend)
+	}
+	if cfg.Storage.Path == "" {
+		cfg.Storage.Path = "data/gateway.db"
+	}
+
+	if cfg.Refresh.IntervalHours < 0 {
+		return fmt.Errorf("refresh.intervalHours must not be negative: %d", cfg.Refresh.IntervalHours)
+	}
+
+	return nil
+}
+
+func loadStatic() error {
+	static := &cfg.Orchestrator.Static
+	if cfg.Orchestrator.Backend != OrchestratorStatic {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	tlsParties := 0
+	for i, party := range static.Parties {
+		if party.Name == "" || party.Address == "" || party.Port == 0 {
+			return fmt.Errorf("orchestrator.static.parties[%d]: name, address and port are required", i)
+		}
+		if names[party.Name] {
+			return fmt.Errorf("orchestrator.static.parties[%d]: duplicate party name %s", i, party.Name)
+		}
+		names[party.Name] = true
+
+		if party.TLS != nil {
+			tlsParties++
+		}
+	}
+	// 파티끼리 직접 통신할 때 TLS 파티는 다른 파티에 TLS로 접속하고 평문 연결은 받지 않으므로, 모든 파티가 TLS이거나 모두 평문이어야 합니다.
+	if cfg.Routing.Mode == RoutingDirect && tlsParties > 0 && tlsParties < len(static.Parties) {
+		return fmt.Errorf("orchestrator.static.parties: with routing.mode %s either all or no parties must use TLS", RoutingDirect)
+	}
 	return nil
 }
 
 func Get() *Config {
 	return &cfg
 }
+
+func loadAutoscale() error {
+	autoscale := &cfg.Autoscale
+	if autoscale.IntervalSeconds <= 0 {
+		autoscale.IntervalSeconds = 15
+	}
+	if autoscale.CooldownSeconds <= 0 {
+		autoscale.CooldownSeconds = 300
+	}
+	if autoscale.MaxWorkers <= 0 {
+		autoscale.MaxWorkers = 20
+	}
+	if !autoscale.Enabled {
+		return nil
+	}
+
+	if cfg.Orchestrator.Backend == OrchestratorStatic {
+		return fmt.Errorf("autoscale cannot be enabled with orchestrator backend %s", OrchestratorStatic)
+	}
+	if autoscale.MinIdle < 0 || autoscale.MinIdle > autoscale.MaxWorkers {
+		return fmt.Errorf("autoscale.minIdle must be between 0 and autoscale.maxWorkers (%d): %d", autoscale.MaxWorkers, autoscale.MinIdle)
+	}
+	return nil
+}
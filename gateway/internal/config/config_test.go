@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadConfig는 임시 디렉터리의 config.yaml에 data를 쓰고 처음부터 다시 읽습니다.
+func loadConfig(t *testing.T, data string) error {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	cfg = Config{}
+	t.Cleanup(func() { cfg = Config{} })
+	return Load()
+}
+
+// staticConfig는 parties를 명단으로 하는 static 오케스트레이터 설정입니다. parties의 "tls"인 파티만 TLS로 접속합니다.
+func staticConfig(mode string, parties ...string) string {
+	var b strings.Builder
+	b.WriteString("routing:\n  mode: \"" + mode + "\"\norchestrator:\n  backend: static\n  static:\n    parties:\n")
+	for i, party := range parties {
+		b.WriteString("    - name: party-" + string(rune('a'+i)) + "\n      address: 10.0.0.1" + string(rune('1'+i)) + "\n      port: 50051\n")
+		if party == "tls" {
+			b.WriteString("      tls:\n        caFile: /etc/tss/ca.pem\n")
+		}
+	}
+	return b.String()
+}
+
+// direct 모드에서는 TLS 파티가 평문 파티에 접속할 수 없으므로 모든 파티가 TLS이거나 모두 평문이어야 합니다.
+func TestLoadStaticTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"direct, all plaintext", staticConfig(RoutingDirect, "plain", "plain", "plain"), false},
+		{"direct, all TLS", staticConfig(RoutingDirect, "tls", "tls", "tls"), false},
+		{"direct, mixed", staticConfig(RoutingDirect, "tls", "plain", "tls"), true},
+		{"relay, mixed", staticConfig(RoutingRelay, "tls", "plain", "tls"), false},
+		{"default routing is direct", staticConfig("", "plain", "tls"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadConfig(t, tt.config)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "all or no parties must use TLS") {
+					t.Fatalf("Load returned %v, want the mixed TLS error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := len(Get().Orchestrator.Static.Parties); got != strings.Count(tt.config, "- name:") {
+				t.Fatalf("loaded %d static parties", got)
+			}
+		})
+	}
+}
+
+func TestLoadStaticRoster(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"missing port", "orchestrator:\n  backend: static\n  static:\n    parties:\n    - name: party-a\n      address: 10.0.0.11\n", "name, address and port are required"},
+		{"missing address", "orchestrator:\n  backend: static\n  static:\n    parties:\n    - name: party-a\n      port: 50051\n", "name, address and port are required"},
+		{"duplicate name", staticConfig(RoutingDirect, "plain", "plain") + "    - name: party-a\n      address: 10.0.0.13\n      port: 50051\n", "duplicate party name party-a"},
+		{"autoscale", staticConfig(RoutingDirect, "plain") + "autoscale:\n  enabled: true\n", "autoscale cannot be enabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := loadConfig(t, tt.config); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load returned %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+
+	// static이 아닌 오케스트레이터는 명단을 확인하지 않습니다.
+	if err := loadConfig(t, strings.Replace(staticConfig(RoutingDirect, "tls", "plain"), "backend: static", "backend: local", 1)); err != nil {
+		t.Fatalf("Load with the local backend: %v", err)
+	}
+}
@@ -2,40 +2,204 @@ package grpc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 
-	"gateway/internal/proto"
+	"gateway/internal/config"
+	"gateway/internal/registry"
+	"proto/tss/v1"
 
 	"google.golang.org/grpc"
-	corev1 "k8s.io/api/core/v1"
+	"google.golang.org/grpc/metadata"
 )
 
-func CallKeygenService(podIP string, n, m int32, allPods []*corev1.Pod) (*proto.KeygenResponse, error) {
-	fmt.Println("podIP:", podIP)
-	conn, err := grpc.Dial(fmt.Sprintf("%s:50051", podIP), grpc.WithInsecure(), grpc.WithBlock())
+const (
+	// keygenTimeout은 실제 tss-lib 키 생성 프로토콜이 끝날 때까지 기다리는 시간입니다.
+	keygenTimeout = 5 * time.Minute
+	// signTimeout은 서명 프로토콜이 끝날 때까지 기다리는 시간입니다.
+	signTimeout = 2 * time.Minute
+	// reshareTimeout은 재공유 프로토콜이 끝날 때까지 기다리는 시간입니다.
+	// 새 위원회 파티가 사전 파라미터를 직접 만들어야 할 수도 있으므로 키 생성만큼 기다립니다.
+	reshareTimeout = 5 * time.Minute
+	// shareTimeout은 키 조각 확정, 폐기, 삭제 요청을 기다리는 시간입니다.
+	shareTimeout = 30 * time.Second
+)
+
+// CallKeygenService는 키 생성 참여 파티 하나에 GenerateKey를 요청합니다.
+// pods에는 자신을 포함한 모든 참여 파티가 들어 있어야 합니다. relayToken은 relay 모드에서 파티가 중계 스트림에 제시할 토큰입니다.
+func CallKeygenService(address, sessionID, relayToken, keyID string, curve tssv1.Curve, n, m int32, pods []*tssv1.PodInfo) (*tssv1.KeygenResponse, error) {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(withRelayToken(context.Background(), relayToken), keygenTimeout)
+	defer cancel()
+
+	req := &tssv1.KeygenRequest{
+		N:         n,
+		M:         m,
+		Pods:      pods,
+		SessionId: sessionID,
+		Routing:   routingMode(),
+		KeyId:     keyID,
+		Curve:     curve,
+	}
+
+	return client.GenerateKey(ctx, req)
+}
+
+// CallSignService는 서명 참여 파티 하나에 Sign을 요청합니다.
+// generation은 키의 현재 세대이며, 파티는 이 세대의 조각으로만 서명합니다.
+func CallSignService(address, sessionID, relayToken, keyID, generation string, message []byte, signers []*tssv1.PodInfo) (*tssv1.SignResponse, error) {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(withRelayToken(context.Background(), relayToken), signTimeout)
+	defer cancel()
+
+	return client.Sign(ctx, &tssv1.SignRequest{
+		SessionId:  sessionID,
+		KeyId:      keyID,
+		Message:    message,
+		Pods:       signers,
+		Routing:    routingMode(),
+		Generation: generation,
+	})
+}
+
+// CallReshareService는 재공유 참여 파티 하나에 Reshare를 요청합니다.
+// generation은 기존 위원회가 사용할 키의 현재 세대입니다.
+func CallReshareService(address, sessionID, relayToken, keyID, generation string, curve tssv1.Curve, oldPods []*tssv1.PodInfo, oldThreshold int32, newPods []*tssv1.PodInfo, newThreshold int32) (*tssv1.ReshareResponse, error) {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to pod %s: %v", podIP, err)
+		return nil, fmt.Errorf("failed to connect to party %s: %v", address, err)
 	}
 	defer conn.Close()
 
-	client := proto.NewKeygenServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(withRelayToken(context.Background(), relayToken), reshareTimeout)
 	defer cancel()
 
-	podInfos := make([]*proto.PodInfo, len(allPods))
-	for i, pod := range allPods {
-		podInfos[i] = &proto.PodInfo{
-			Ip:   pod.Status.PodIP,
-			Port: 50051, // 모든 Pod이 같은 포트를 사용한다고 가정
+	return client.Reshare(ctx, &tssv1.ReshareRequest{
+		SessionId:    sessionID,
+		KeyId:        keyID,
+		Curve:        curve,
+		OldPods:      oldPods,
+		OldThreshold: oldThreshold,
+		NewPods:      newPods,
+		NewThreshold: newThreshold,
+		Routing:      routingMode(),
+		Generation:   generation,
+	})
+}
+
+// CallCommitShare는 파티에 재공유로 준비된 generation 세대의 키 조각을 확정하도록 요청합니다.
+func CallCommitShare(address, keyID, generation string) error {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), shareTimeout)
+	defer cancel()
+
+	_, err = client.CommitShare(ctx, &tssv1.CommitShareRequest{KeyId: keyID, Generation: generation})
+	return err
+}
+
+// CallAbortShare는 파티에 실패한 재공유로 준비된 generation 세대의 키 조각을 버리도록 요청합니다.
+func CallAbortShare(address, keyID, generation string) error {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), shareTimeout)
+	defer cancel()
+
+	_, err = client.AbortShare(ctx, &tssv1.AbortShareRequest{KeyId: keyID, Generation: generation})
+	return err
+}
+
+// CallDeleteShare는 파티에 키 조각 삭제를 요청합니다.
+func CallDeleteShare(address, keyID string) error {
+	conn, err := grpc.Dial(address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	client := tssv1.NewKeygenServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), shareTimeout)
+	defer cancel()
+
+	_, err = client.DeleteShare(ctx, &tssv1.DeleteShareRequest{KeyId: keyID})
+	return err
+}
+
+// Curves는 HTTP 요청과 키 메타데이터의 곡선 이름을 파티에게 전달할 값으로 바꿉니다.
+var Curves = map[string]tssv1.Curve{
+	registry.CurveSecp256k1: tssv1.Curve_CURVE_SECP256K1,
+	registry.CurveEd25519:   tssv1.Curve_CURVE_ED25519,
+}
+
+// PodInfoOf는 키 메타데이터의 파티를 파티에게 전달할 PodInfo로 바꿉니다.
+func PodInfoOf(party registry.Party) *tssv1.PodInfo {
+	info := &tssv1.PodInfo{Ip: party.IP, Port: party.Port, Name: party.Name}
+	if party.Key != "" {
+		// Key는 게이트웨이가 만든 hex 값이므로 디코딩에 실패하지 않습니다.
+		info.PartyKey, _ = hex.DecodeString(party.Key)
+	}
+	return info
+}
+
+// PodInfosOf는 파티 목록을 PodInfo 목록으로 바꿉니다.
+func PodInfosOf(parties []registry.Party) []*tssv1.PodInfo {
+	infos := make([]*tssv1.PodInfo, len(parties))
+	for i, party := range parties {
+		infos[i] = PodInfoOf(party)
+	}
+	return infos
+}
+
+// SetTokenHashes는 pods의 token_hash에 각 파티의 세션 토큰의 SHA-256을 넣습니다.
+// 파티는 이 값으로 direct 모드에서 받은 메시지의 발신자를 확인하며, 토큰 자체는 그 파티에게만 보냅니다.
+func SetTokenHashes(tokens map[string]string, pods ...[]*tssv1.PodInfo) {
+	for _, list := range pods {
+		for _, pod := range list {
+			if token, ok := tokens[pod.Name]; ok {
+				sum := sha256.Sum256([]byte(token))
+				pod.TokenHash = sum[:]
+			}
 		}
 	}
+}
 
-	req := &proto.KeygenRequest{
-		N:    n,
-		M:    m,
-		Pods: podInfos,
+// withRelayToken은 세션 요청에 파티의 세션 토큰을 붙입니다.
+func withRelayToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
 	}
+	return metadata.AppendToOutgoingContext(ctx, tokenMetadata, token)
+}
 
-	return client.GenerateKey(ctx, req)
+// routingMode는 설정된 라우팅 방식을 파티에게 전달할 값으로 바꿉니다.
+func routingMode() tssv1.RoutingMode {
+	if config.Get().Routing.Mode == config.RoutingRelay {
+		return tssv1.RoutingMode_ROUTING_MODE_RELAY
+	}
+	return tssv1.RoutingMode_ROUTING_MODE_DIRECT
 }
@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"gateway/internal/config"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// relayBufferSize는 파티마다 전달을 기다리는 메시지의 최대 수입니다.
+const relayBufferSize = 1024
+
+// 중계 스트림의 메타데이터. 파티는 세션마다 스트림을 열고, 게이트웨이가 세션 요청과 함께 보낸 토큰으로 자신을 증명합니다.
+const (
+	partyMetadata   = "party"
+	sessionMetadata = "session"
+	tokenMetadata   = "relay-token"
+)
+
+// RelayServer는 파티 사이의 라운드 메시지를 세션과 수신 파티별로 중계합니다.
+// 각 파티는 세션마다 게이트웨이와 양방향 스트림 하나를 열고, 게이트웨이는 받은 메시지를
+// 수신 파티의 그 세션 스트림으로 보냅니다.
+//
+// 라운드 메시지에는 파티 사이의 비밀 조각이 들어 있으므로, 게이트웨이는 세션을 열 때 파티마다 임의의 토큰을 만들어
+// 세션 요청(GenerateKey, Sign, Reshare)의 메타데이터로 보내고, 그 토큰을 제시한 스트림만 그 파티의 메시지를 받게 합니다.
+// 세션이 닫히면 전달되지 않은 메시지와 함께 세션의 스트림을 닫습니다.
+type RelayServer struct {
+	tssv1.UnimplementedRelayServiceServer
+
+	mu       sync.Mutex
+	sessions map[string]*relaySession
+}
+
+// relaySession은 중계 중인 세션 하나입니다. tokens와 outboxes는 세션을 열 때 만든 뒤 바뀌지 않습니다.
+type relaySession struct {
+	tokens   map[string]string
+	outboxes map[string]chan *tssv1.RoundMessage
+	closed   chan struct{}
+}
+
+func NewRelayServer() *RelayServer {
+	return &RelayServer{
+		sessions: make(map[string]*relaySession),
+	}
+}
+
+// OpenSession은 세션과 참여 파티를 등록하고 파티별 중계 토큰을 반환합니다. 등록된 세션의 메시지만 중계합니다.
+func (s *RelayServer) OpenSession(sessionID string, parties []string) (map[string]string, error) {
+	tokens, err := newTokens(parties)
+	if err != nil {
+		return nil, err
+	}
+	session := &relaySession{
+		tokens:   tokens,
+		outboxes: make(map[string]chan *tssv1.RoundMessage, len(parties)),
+		closed:   make(chan struct{}),
+	}
+	for _, party := range parties {
+		session.outboxes[party] = make(chan *tssv1.RoundMessage, relayBufferSize)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sessionID] = session
+	return session.tokens, nil
+}
+
+// OpenRelaySession은 파티별 세션 토큰과 해제 함수를 반환하며, relay 모드일 때는 세션을 중계 대상으로 등록합니다.
+// direct 모드에서도 토큰을 만듭니다. 파티는 다른 파티에게 직접 보내는 메시지에 자신의 토큰을 붙이고,
+// 받는 파티는 세션 요청의 PodInfo.token_hash(SetTokenHashes)로 발신자를 확인합니다.
+func OpenRelaySession(relayServer *RelayServer, sessionID string, parties []string) (map[string]string, func(), error) {
+	if config.Get().Routing.Mode != config.RoutingRelay {
+		tokens, err := newTokens(parties)
+		return tokens, func() {}, err
+	}
+	tokens, err := relayServer.OpenSession(sessionID, parties)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, func() {
+		relayServer.CloseSession(sessionID)
+	}, nil
+}
+
+// newTokens는 파티마다 임의의 세션 토큰을 만듭니다.
+func newTokens(parties []string) (map[string]string, error) {
+	tokens := make(map[string]string, len(parties))
+	for _, party := range parties {
+		token := make([]byte, 32)
+		if _, err := rand.Read(token); err != nil {
+			return nil, fmt.Errorf("failed to create relay token: %v", err)
+		}
+		tokens[party] = hex.EncodeToString(token)
+	}
+	return tokens, nil
+}
+
+// CloseSession은 세션 등록을 해제하고, 세션의 스트림을 닫으며 전달되지 않은 메시지를 버립니다.
+func (s *RelayServer) CloseSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if session, ok := s.sessions[sessionID]; ok {
+		close(session.closed)
+		delete(s.sessions, sessionID)
+	}
+}
+
+// Relay는 파티 하나의 세션 스트림을 처리합니다. 파티는 "party", "session", "relay-token" 메타데이터로
+// 자신의 이름, 세션과 게이트웨이에게 받은 토큰을 알립니다. 세션이 닫히면 스트림을 닫습니다.
+func (s *RelayServer) Relay(stream tssv1.RelayService_RelayServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	name, sessionID, token := firstValue(md, partyMetadata), firstValue(md, sessionMetadata), firstValue(md, tokenMetadata)
+	if name == "" || sessionID == "" || token == "" {
+		return status.Error(codes.InvalidArgument, "party, session and relay-token metadata are required")
+	}
+
+	s.mu.Lock()
+	session, ok := s.sessions[sessionID]
+	s.mu.Unlock()
+	if !ok || subtle.ConstantTimeCompare([]byte(session.tokens[name]), []byte(token)) != 1 {
+		log.Printf("Rejected relay stream of party %s for session %s", name, sessionID)
+		return status.Error(codes.PermissionDenied, "invalid relay token")
+	}
+	log.Printf("Relay stream opened by party %s for session %s", name, sessionID)
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			s.forward(sessionID, session, name, msg)
+		}
+	}()
+
+	// 수신 파티가 아직 연결되지 않았다면 연결될 때까지 outbox에 쌓아 둡니다.
+	outbox := session.outboxes[name]
+	for {
+		select {
+		case msg := <-outbox:
+			if err := stream.Send(msg); err != nil {
+				log.Printf("Failed to relay message to party %s: %v", name, err)
+				return err
+			}
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-session.closed:
+			return nil
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *RelayServer) forward(sessionID string, session *relaySession, sender string, msg *tssv1.RoundMessage) {
+	from, to := memberName(msg.From), memberName(msg.To)
+	outbox, ok := session.outboxes[to]
+	if msg.SessionId != sessionID || from != sender || !ok {
+		log.Printf("Dropping relay message %s -> %s in session %s", msg.From, msg.To, msg.SessionId)
+		return
+	}
+
+	select {
+	case outbox <- msg:
+	default:
+		log.Printf("Relay outbox for party %s is full, dropping message", to)
+	}
+}
+
+// memberName은 라운드 메시지의 from/to에서 파티 이름을 꺼냅니다.
+// 재공유에서 새 위원회 역할은 "<이름>#new"로 표시됩니다.
+func memberName(id string) string {
+	return strings.TrimSuffix(id, "#new")
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
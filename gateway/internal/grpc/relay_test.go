@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"gateway/internal/config"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startRelay는 메모리 연결로 RelayServer를 실행하고 클라이언트를 반환합니다.
+func startRelay(t *testing.T) (*RelayServer, tssv1.RelayServiceClient) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	relay := NewRelayServer()
+	server := grpc.NewServer()
+	tssv1.RegisterRelayServiceServer(server, relay)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return relay, tssv1.NewRelayServiceClient(conn)
+}
+
+func openStream(t *testing.T, client tssv1.RelayServiceClient, party, sessionID, token string) tssv1.RelayService_RelayClient {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	ctx = metadata.AppendToOutgoingContext(ctx, partyMetadata, party, sessionMetadata, sessionID, tokenMetadata, token)
+	stream, err := client.Relay(ctx)
+	if err != nil {
+		t.Fatalf("Relay: %v", err)
+	}
+	return stream
+}
+
+func TestRelayForwardsToTokenHolder(t *testing.T) {
+	relay, client := startRelay(t)
+	tokens, err := relay.OpenSession("session-1", []string{"party-a", "party-b"})
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+
+	a := openStream(t, client, "party-a", "session-1", tokens["party-a"])
+	b := openStream(t, client, "party-b", "session-1", tokens["party-b"])
+	sent := &tssv1.RoundMessage{SessionId: "session-1", From: "party-a", To: "party-b", Payload: []byte("round 1")}
+	if err := a.Send(sent); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	got, err := b.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if string(got.Payload) != "round 1" {
+		t.Fatalf("received %q, want %q", got.Payload, "round 1")
+	}
+}
+
+func TestRelayRejectsWrongToken(t *testing.T) {
+	relay, client := startRelay(t)
+	tokens, err := relay.OpenSession("session-1", []string{"party-a", "party-b"})
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+
+	tests := []struct {
+		name, party, session, token string
+	}{
+		{"another party's token", "party-b", "session-1", tokens["party-a"]},
+		{"unknown session", "party-a", "session-2", tokens["party-a"]},
+		{"no token", "party-a", "session-1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := openStream(t, client, tt.party, tt.session, tt.token)
+			_, err := stream.Recv()
+			if code := status.Code(err); code != codes.PermissionDenied && code != codes.InvalidArgument {
+				t.Fatalf("Recv returned %v, want PermissionDenied or InvalidArgument", err)
+			}
+		})
+	}
+}
+
+// 세션이 닫히면 세션의 스트림이 끝나고 세션의 outbox는 버려집니다.
+func TestRelayClosesStreamsWithSession(t *testing.T) {
+	relay, client := startRelay(t)
+	tokens, err := relay.OpenSession("session-1", []string{"party-a", "party-b"})
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+	a := openStream(t, client, "party-a", "session-1", tokens["party-a"])
+	b := openStream(t, client, "party-b", "session-1", tokens["party-b"])
+	// 메시지가 전달되면 두 스트림이 모두 세션에 연결된 것입니다.
+	if err := a.Send(&tssv1.RoundMessage{SessionId: "session-1", From: "party-a", To: "party-b"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := b.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	relay.CloseSession("session-1")
+	for name, stream := range map[string]tssv1.RelayService_RelayClient{"party-a": a, "party-b": b} {
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("Recv on the stream of %s after CloseSession returned %v, want EOF", name, err)
+		}
+	}
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+	if len(relay.sessions) != 0 {
+		t.Fatalf("%d sessions remain after CloseSession", len(relay.sessions))
+	}
+}
+
+// direct 모드에서도 파티별 토큰을 만들어, 파티가 받은 메시지의 발신자를 확인할 수 있게 합니다.
+func TestDirectSessionTokens(t *testing.T) {
+	config.Get().Routing.Mode = config.RoutingDirect
+	relay := NewRelayServer()
+	tokens, closeSession, err := OpenRelaySession(relay, "session-1", []string{"party-a", "party-b"})
+	if err != nil {
+		t.Fatalf("OpenRelaySession: %v", err)
+	}
+	defer closeSession()
+	if len(tokens) != 2 || tokens["party-a"] == "" || tokens["party-a"] == tokens["party-b"] {
+		t.Fatalf("tokens = %v, want a distinct token per party", tokens)
+	}
+	if len(relay.sessions) != 0 {
+		t.Fatal("direct session was registered for relaying")
+	}
+
+	pods := []*tssv1.PodInfo{{Name: "party-a"}, {Name: "party-b"}}
+	SetTokenHashes(tokens, pods)
+	for _, pod := range pods {
+		sum := sha256.Sum256([]byte(tokens[pod.Name]))
+		if !bytes.Equal(pod.TokenHash, sum[:]) {
+			t.Fatalf("token_hash of %s does not match its token", pod.Name)
+		}
+	}
+}
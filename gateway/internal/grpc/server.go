@@ -1,50 +1,85 @@
 package grpc
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"net"
 
-	"gateway/internal/proto"
+	"gateway/internal/config"
+	"gateway/internal/session"
+	"proto/tss/v1"
 
 	"google.golang.org/grpc"
 )
 
 type KeygenServiceServer struct {
-	proto.UnimplementedKeygenServiceServer
-	FinishedKeys chan string
+	tssv1.UnimplementedKeygenServiceServer
+	Sessions *session.Registry
 }
 
-func NewKeygenServiceServer() *KeygenServiceServer {
+func NewKeygenServiceServer(sessions *session.Registry) *KeygenServiceServer {
 	return &KeygenServiceServer{
-		FinishedKeys: make(chan string, 100),
+		Sessions: sessions,
 	}
 }
 
 // KeygenFinished는 Pod로부터 키 생성 완료 메시지를 스트리밍으로 받는 gRPC 메서드입니다.
-func (s *KeygenServiceServer) KeygenFinished(stream proto.KeygenService_KeygenFinishedServer) error {
+// 완료 메시지는 세션 ID로 해당 요청의 세션에만 전달됩니다.
+func (s *KeygenServiceServer) KeygenFinished(stream tssv1.KeygenService_KeygenFinishedServer) error {
 	for {
 		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&tssv1.KeygenFinishedResponse{Message: "ok"})
+		}
 		if err != nil {
 			if err == grpc.ErrServerStopped {
 				return nil
 			}
 			return err
 		}
-		s.FinishedKeys <- req.Publickey
+
+		err = s.Sessions.Report(req.SessionId, session.Result{
+			Party:     req.Party,
+			PublicKey: req.Publickey,
+		})
+		if err != nil {
+			log.Printf("Ignoring KeygenFinished from party %s: %v", req.Party, err)
+		}
 	}
 }
 
+// KeygenProgress는 파티가 보고한 현재 라운드를 세션에 기록합니다.
+func (s *KeygenServiceServer) KeygenProgress(ctx context.Context, req *tssv1.KeygenProgressRequest) (*tssv1.KeygenProgressResponse, error) {
+	if err := s.Sessions.Progress(req.SessionId, req.Party, int(req.Round)); err != nil {
+		log.Printf("Ignoring KeygenProgress from party %s: %v", req.Party, err)
+	}
+	return &tssv1.KeygenProgressResponse{}, nil
+}
+
 // StartGRPCServer는 gRPC 서버를 시작합니다.
-func StartGRPCServer(server *KeygenServiceServer) {
-	lis, err := net.Listen("tcp", ":50051")
+func StartGRPCServer(server *KeygenServiceServer, relay *RelayServer) {
+	port := config.Get().GRPC.Port
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterKeygenServiceServer(grpcServer, server)
+	var opts []grpc.ServerOption
+	if tlsConfig := config.Get().GRPC.TLS; tlsConfig != nil {
+		creds, err := LoadServerTLSCredentials(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.ClientCAFile)
+		if err != nil {
+			log.Fatalf("Failed to load gRPC server TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	tssv1.RegisterKeygenServiceServer(grpcServer, server)
+	tssv1.RegisterRelayServiceServer(grpcServer, relay)
 
-	log.Println("gRPC server is running on port 50051")
+	log.Printf("gRPC server is running on port %d", port)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthTimeout은 파티 헬스 체크 하나를 기다리는 시간입니다.
+const healthTimeout = 5 * time.Second
+
+// partyCredentials는 TLS로 접속해야 하는 파티의 주소별 자격 증명입니다.
+// 등록되지 않은 주소(Kubernetes, 로컬 파티)는 지금처럼 평문으로 접속합니다.
+var (
+	credentialsMu    sync.RWMutex
+	partyCredentials = make(map[string]credentials.TransportCredentials)
+)
+
+// SetPartyCredentials는 address의 파티에 접속할 때 사용할 TLS 자격 증명을 등록합니다.
+func SetPartyCredentials(address string, creds credentials.TransportCredentials) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	partyCredentials[address] = creds
+}
+
+// LoadTLSCredentials는 파티 인증서를 caFile로 검증하는 TLS 자격 증명을 만듭니다.
+// serverName은 파티 인증서에 있어야 하는 이름이며, certFile과 keyFile이 있으면 게이트웨이 클라이언트 인증서로 제시합니다(mTLS).
+func LoadTLSCredentials(serverName, caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// transportOption은 address의 파티에 맞는 전송 방식을 반환합니다.
+func transportOption(address string) grpc.DialOption {
+	credentialsMu.RLock()
+	defer credentialsMu.RUnlock()
+	if creds, ok := partyCredentials[address]; ok {
+		return grpc.WithTransportCredentials(creds)
+	}
+	return grpc.WithInsecure()
+}
+
+// LoadServerTLSCredentials는 게이트웨이 gRPC 서버의 TLS 자격 증명을 만듭니다. clientCAFile이 있으면 클라이언트 인증서를 요구합니다.
+func LoadServerTLSCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// CheckHealth는 파티의 표준 gRPC 헬스 서비스에 상태를 묻습니다.
+// 파티는 키 생성을 받을 준비가 되면 SERVING을 보고합니다.
+func CheckHealth(address string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, transportOption(address), grpc.WithBlock())
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	return resp.Status, nil
+}
@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testPKI는 테스트용 CA와 CA가 발급한 인증서 파일의 경로입니다.
+type testPKI struct {
+	dir string
+	ca  *x509.Certificate
+	key *ecdsa.PrivateKey
+	// caFile은 CA 인증서 파일입니다.
+	caFile string
+}
+
+func newTestPKI(t *testing.T, name string) *testPKI {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	pki := &testPKI{dir: t.TempDir(), ca: ca, key: key}
+	pki.caFile = pki.write(t, name+".pem", "CERTIFICATE", der)
+	return pki
+}
+
+// issue는 CA가 name에게 발급한 인증서와 키를 파일로 쓰고 경로를 반환합니다. hosts는 인증서의 DNS 이름 또는 IP입니다.
+func (p *testPKI) issue(t *testing.T, name string, hosts ...string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, p.ca, &key.PublicKey, p.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	return p.write(t, name+".pem", "CERTIFICATE", der), p.write(t, name+"-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func (p *testPKI) write(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(p.dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+// startTLSParty는 TLS 헬스 서비스만 제공하는 파티를 실행하고 주소를 반환합니다. clientCAFile이 있으면 클라이언트 인증서를 요구합니다.
+func startTLSParty(t *testing.T, certFile, keyFile, clientCAFile string) string {
+	t.Helper()
+	creds, err := LoadServerTLSCredentials(certFile, keyFile, clientCAFile)
+	if err != nil {
+		t.Fatalf("LoadServerTLSCredentials: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// 생성한 CA로 파티 인증서를 검증하고 게이트웨이 인증서를 제시해(mTLS) 헬스 체크를 합니다.
+func TestTLSCredentials(t *testing.T) {
+	pki := newTestPKI(t, "ca")
+	partyCert, partyKey := pki.issue(t, "party-a", "party-a.tss.internal", "127.0.0.1")
+	gatewayCert, gatewayKey := pki.issue(t, "gateway")
+	address := startTLSParty(t, partyCert, partyKey, pki.caFile)
+
+	other := newTestPKI(t, "other-ca")
+	otherCert, otherKey := other.issue(t, "gateway")
+
+	tests := []struct {
+		name               string
+		serverName, caFile string
+		certFile, keyFile  string
+		serving            bool
+	}{
+		{"mutual TLS", "party-a.tss.internal", pki.caFile, gatewayCert, gatewayKey, true},
+		{"verified by address", "127.0.0.1", pki.caFile, gatewayCert, gatewayKey, true},
+		{"wrong server name", "party-b.tss.internal", pki.caFile, gatewayCert, gatewayKey, false},
+		{"party certificate from another CA", "party-a.tss.internal", other.caFile, gatewayCert, gatewayKey, false},
+		{"no client certificate", "party-a.tss.internal", pki.caFile, "", "", false},
+		{"client certificate from another CA", "party-a.tss.internal", pki.caFile, otherCert, otherKey, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := LoadTLSCredentials(tt.serverName, tt.caFile, tt.certFile, tt.keyFile)
+			if err != nil {
+				t.Fatalf("LoadTLSCredentials: %v", err)
+			}
+			if tt.serving {
+				SetPartyCredentials(address, creds)
+				if status, err := CheckHealth(address); err != nil || status != healthpb.HealthCheckResponse_SERVING {
+					t.Fatalf("CheckHealth = %s, %v; want SERVING", status, err)
+				}
+				return
+			}
+			// CheckHealth는 실패한 연결을 healthTimeout까지 다시 시도하므로, 실패는 한 번만 호출해 확인합니다.
+			conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+			if err != nil {
+				t.Fatalf("Dial: %v", err)
+			}
+			defer conn.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+			defer cancel()
+			if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err == nil {
+				t.Fatal("health check succeeded, want a TLS error")
+			}
+		})
+	}
+}
+
+func TestLoadTLSCredentialsErrors(t *testing.T) {
+	pki := newTestPKI(t, "ca")
+	certFile, keyFile := pki.issue(t, "gateway")
+	otherCert, _ := pki.issue(t, "other")
+	notPEM := pki.write(t, "not-a-cert.pem", "PRIVATE KEY", []byte("key"))
+	missing := filepath.Join(pki.dir, "missing.pem")
+
+	tests := []struct {
+		name                      string
+		caFile, certFile, keyFile string
+	}{
+		{"missing CA file", missing, certFile, keyFile},
+		{"CA file without certificates", notPEM, certFile, keyFile},
+		{"missing certificate", pki.caFile, missing, keyFile},
+		{"certificate without key", pki.caFile, certFile, ""},
+		{"key of another certificate", pki.caFile, otherCert, keyFile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadTLSCredentials("party-a", tt.caFile, tt.certFile, tt.keyFile); err == nil {
+				t.Fatal("LoadTLSCredentials succeeded")
+			}
+		})
+	}
+
+	if _, err := LoadServerTLSCredentials(certFile, keyFile, notPEM); err == nil {
+		t.Fatal("LoadServerTLSCredentials accepted a client CA file without certificates")
+	}
+	if _, err := LoadServerTLSCredentials(otherCert, keyFile, ""); err == nil {
+		t.Fatal("LoadServerTLSCredentials accepted the key of another certificate")
+	}
+}
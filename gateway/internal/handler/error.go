@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"errors"
+
+	"gateway/internal/orchestrator"
+	"gateway/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sendError는 에러 코드에 맞는 상태 코드로 ErrorResponse를 응답합니다.
+func sendError(c *gin.Context, errorCode string, customMessage ...string) {
+	resp := response.NewErrorResponse(errorCode, customMessage...)
+	c.JSON(resp.StatusCode, resp)
+}
+
+// capacityErrorCode는 파티를 빌리지 못해 실패한 에러의 에러 코드를 반환합니다.
+func capacityErrorCode(err error) (string, bool) {
+	switch {
+	case errors.Is(err, orchestrator.ErrCapacityExhausted):
+		return response.ErrCapacityExhausted, true
+	case errors.Is(err, orchestrator.ErrQueueFull):
+		return response.ErrQueueFull, true
+	}
+	return "", false
+}
@@ -1,80 +1,301 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"gateway/internal/config"
 	grpcClient "gateway/internal/grpc"
-	"gateway/internal/k8s"
+	"gateway/internal/job"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+	"gateway/pkg/response"
 )
 
+// KeygenRequest의 Curve는 secp256k1(기본값) 또는 ed25519입니다.
+// Async가 true이면 키 생성을 작업으로 실행하고 바로 응답합니다.
+// Labels는 키에 붙일 레이블이며 GET /keys에서 키를 거르는 데 사용합니다.
+// Priority는 파티가 부족할 때 대기열에서의 우선순위(low, normal, high)이며 기본값은 normal입니다.
 type KeygenRequest struct {
-	N int `json:"n" binding:"required"`
-	M int `json:"m" binding:"required"`
+	N        int               `json:"n" binding:"required"`
+	M        int               `json:"m" binding:"required"`
+	Curve    string            `json:"curve"`
+	Async    bool              `json:"async"`
+	Labels   map[string]string `json:"labels"`
+	Priority string            `json:"priority"`
 }
 
 type KeygenResponse struct {
+	KeyID     string `json:"key_id"`
+	Curve     string `json:"curve"`
 	PublicKey string `json:"publickey"`
+
+	// Parties는 각 파티가 보고한 공개키 지문으로, 키 생성 과정을 감사하는 데 사용합니다.
+	Parties []session.PartyKey `json:"parties"`
+}
+
+// keyAgreementResponse는 파티들의 공개키가 일치하지 않을 때 파티별 지문을 함께 응답합니다.
+type keyAgreementResponse struct {
+	*response.ErrorResponse
+	Parties []session.PartyKey `json:"parties"`
+}
+
+type KeygenJobResponse struct {
+	JobID string `json:"job_id"`
+	State string `json:"state"`
 }
 
 // Keygen은 HTTP 요청을 처리하는 핸들러 함수입니다.
-func Keygen(keygenServer *grpcClient.KeygenServiceServer) gin.HandlerFunc {
+// async가 true이면 작업을 만들고 바로 202와 작업 ID를 반환하며, 진행 상태는 GET /keygen/{id}로 조회합니다.
+func Keygen(keygenServer *grpcClient.KeygenServiceServer, relayServer *grpcClient.RelayServer, keys *registry.Registry, jobs *job.Store, orch orchestrator.Orchestrator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req KeygenRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-
-		// 대기 풀에서 리소스가 여유 있는 Pod 가져오기
-		pods, err := k8s.GetPodsFromPool(req.M)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		// 파티를 빌리기 전에 확인합니다. 음수 m으로 대기 풀의 모든 파티를 빌리거나 끝날 수 없는 키 생성을 실행하지 않습니다.
+		switch {
+		case req.M < 2:
+			sendError(c, response.ErrMNotPositive, "m은 2 이상이어야 합니다")
+			return
+		case req.N < 1:
+			sendError(c, response.ErrNNotPositive)
+			return
+		case req.N >= req.M:
+			sendError(c, response.ErrMGreaterThanN, "n은 m보다 작아야 합니다")
 			return
 		}
 
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-		var publicKeys []string
+		if req.Curve == "" {
+			req.Curve = registry.CurveSecp256k1
+		}
+		if _, ok := grpcClient.Curves[req.Curve]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported curve: " + req.Curve})
+			return
+		}
+		if err := registry.ValidateLabels(req.Labels); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if _, err := orchestrator.ParsePriority(req.Priority); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-		for _, pod := range pods {
-			wg.Add(1)
-			go func(podIP string) {
-				defer wg.Done()
-				// Pod의 키 생성 서비스를 호출합니다.
-				_, err := grpcClient.CallKeygenService(podIP, int32(req.N), int32(req.M), pods)
-				if err != nil {
-					log.Printf("Failed to call keygen service on pod %s: %v", podIP, err)
-				}
-			}(pod.Status.PodIP)
+		if !req.Async {
+			resp, err := keygen(c.Request.Context(), keygenServer, relayServer, keys, orch, req, func(string) {}, func(string) {})
+			if err != nil && c.Request.Context().Err() != nil {
+				sendError(c, response.ErrRequestCanceled, err.Error())
+				return
+			}
+			var agreementErr *session.AgreementError
+			if errors.As(err, &agreementErr) {
+				resp := response.NewErrorResponse(response.ErrKeyAgreement, agreementErr.Error())
+				c.JSON(resp.StatusCode, keyAgreementResponse{ErrorResponse: resp, Parties: agreementErr.Parties})
+				return
+			}
+			if code, ok := capacityErrorCode(err); ok {
+				sendError(c, code, err.Error())
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+			return
 		}
 
-		// 키 생성 완료 메시지를 수신
+		j := jobs.Create()
+		// 작업은 요청 컨텍스트와 무관하게 실행되므로 클라이언트 연결이 끊겨도 계속됩니다.
 		go func() {
-			for key := range keygenServer.FinishedKeys {
-				mu.Lock()
-				publicKeys = append(publicKeys, key)
-				mu.Unlock()
-				if len(publicKeys) == req.M {
-					close(keygenServer.FinishedKeys)
+			resp, err := keygen(context.Background(), keygenServer, relayServer, keys, orch, req, func(sessionID string) {
+				jobs.Update(j.ID, func(j *job.Job) {
+					j.SessionID = sessionID
+				})
+			}, func(string) {
+				jobs.Update(j.ID, func(j *job.Job) {
+					j.State = job.StateRunning
+				})
+			})
+			jobs.Update(j.ID, func(j *job.Job) {
+				if err != nil {
+					j.State = job.StateFailed
+					j.Error = err.Error()
+					var agreementErr *session.AgreementError
+					if errors.As(err, &agreementErr) {
+						j.ErrorCode = response.ErrKeyAgreement
+						j.Parties = agreementErr.Parties
+					}
+					if code, ok := capacityErrorCode(err); ok {
+						j.ErrorCode = code
+					}
+					return
 				}
-			}
+				j.State = job.StateSucceeded
+				j.KeyID = resp.KeyID
+				j.Curve = resp.Curve
+				j.PublicKey = resp.PublicKey
+				j.Parties = resp.Parties
+			})
 		}()
 
-		// 모든 고루틴이 완료될 때까지 대기
-		wg.Wait()
+		c.Header("Location", "/keygen/"+j.ID)
+		c.JSON(http.StatusAccepted, KeygenJobResponse{JobID: j.ID, State: j.State})
+	}
+}
 
-		// 키 생성 완료 메시지를 수신할 때까지 스트리밍을 통해 대기
-		if len(publicKeys) == 0 {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate keys"})
+// KeygenJob은 비동기 키 생성 작업의 상태를 반환합니다.
+// 파티를 기다리는 작업은 대기열에서의 순서를, 실행 중인 작업은 파티들이 보고한 현재 라운드를 함께 반환합니다.
+func KeygenJob(keygenServer *grpcClient.KeygenServiceServer, jobs *job.Store, orch orchestrator.Orchestrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		j, ok := jobs.Get(c.Param("id"))
+		if !ok {
+			sendError(c, response.ErrJobNotFound)
 			return
 		}
 
-		// 생성된 첫 번째 공개키를 응답으로 반환합니다.
-		// 주의: 실제 구현에서는 모든 키를 결합하거나 처리하는 로직이 필요할 수 있습니다.
-		c.JSON(http.StatusOK, KeygenResponse{PublicKey: publicKeys[0]})
+		if j.State == job.StatePending && j.SessionID != "" {
+			j.QueuePosition = orch.QueuePosition(j.SessionID)
+		}
+		if j.State == job.StateRunning {
+			if sess, ok := keygenServer.Sessions.Get(j.SessionID); ok {
+				j.Round = sess.Round()
+			}
+		}
+		c.JSON(http.StatusOK, j)
+	}
+}
+
+// keygen은 대기 풀의 파티들과 키 생성을 실행하고 생성된 키를 등록합니다.
+// onQueued는 파티를 빌리기 전에, onStart는 파티들에게 키 생성을 요청하기 직전에 세션 ID와 함께 호출됩니다.
+func keygen(ctx context.Context, keygenServer *grpcClient.KeygenServiceServer, relayServer *grpcClient.RelayServer, keys *registry.Registry, orch orchestrator.Orchestrator, req KeygenRequest, onQueued, onStart func(sessionID string)) (*KeygenResponse, error) {
+	curve := grpcClient.Curves[req.Curve]
+
+	// 파티들은 세션 ID로 서로의 라운드 메시지를 구분하고, 키 조각은 키 ID로 보관합니다.
+	sessionID := uuid.NewString()
+	keyID := uuid.NewString()
+
+	// 대기 풀에서 파티를 빌리고, 세션이 끝나면 성공 여부와 관계없이 돌려줍니다.
+	// 파티가 부족하면 대기열에서 기다립니다.
+	onQueued(sessionID)
+	priority, _ := orchestrator.ParsePriority(req.Priority)
+	workers, err := waitForParties(ctx, orch, orchestrator.Request{Owner: sessionID, N: req.M, Priority: priority})
+	if err != nil {
+		return nil, err
+	}
+	defer orch.Release(sessionID)
+
+	parties := make([]registry.Party, len(workers))
+	names := make([]string, len(workers))
+	for i, worker := range workers {
+		parties[i] = registry.Party{Name: worker.Name, IP: worker.IP, Port: worker.Port}
+		names[i] = worker.Name
+	}
+	pods := grpcClient.PodInfosOf(parties)
+
+	tokens, closeRelay, err := grpcClient.OpenRelaySession(relayServer, sessionID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRelay()
+	grpcClient.SetTokenHashes(tokens, pods)
+
+	// 완료 보고는 세션 ID로 이 요청의 세션에만 모입니다.
+	sess := keygenServer.Sessions.Open(sessionID, names)
+	defer keygenServer.Sessions.Close(sessionID)
+	onStart(sessionID)
+
+	var calls sync.WaitGroup
+	for _, worker := range workers {
+		calls.Add(1)
+		go func(worker orchestrator.Worker) {
+			defer calls.Done()
+			// 파티의 키 생성 서비스를 호출하고, 응답의 공개키를 파티의 완료 보고로 세션에 기록합니다.
+			// 파티가 KeygenFinished로 다른 공개키를 보고하면 세션이 실패합니다.
+			resp, err := grpcClient.CallKeygenService(worker.Address(), sessionID, tokens[worker.Name], keyID, curve, int32(req.N), int32(req.M), pods)
+			if err != nil {
+				log.Printf("Failed to call keygen service on party %s: %v", worker.Name, err)
+				sess.Fail(fmt.Errorf("keygen failed on party %s: %v", worker.Name, err))
+				return
+			}
+			if err := keygenServer.Sessions.Report(sessionID, session.Result{Party: worker.Name, PublicKey: resp.Publickey}); err != nil {
+				log.Printf("Ignoring keygen response from party %s: %v", worker.Name, err)
+			}
+		}(worker)
+	}
+
+	// 키를 등록하지 못하면 먼저 끝난 파티가 저장한 조각이 남으므로 모든 파티의 조각을 지웁니다.
+	registered := false
+	defer func() {
+		if !registered {
+			go deleteShares(&calls, keyID, workers)
+		}
+	}()
+
+	// 모든 파티가 완료를 보고하거나 세션이 실패 또는 타임아웃될 때까지 대기
+	timeout := time.Duration(config.Get().Session.TimeoutSeconds) * time.Second
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// 타임아웃으로 끝났다면 보고하지 않은 파티가 있으므로 아래 합의 확인에서 실패합니다.
+	// 요청이 취소되었다면(클라이언트 연결 끊김) 파티들의 공개키가 다른 것이 아니므로 취소로 실패합니다.
+	results, err := sess.Wait(waitCtx)
+	if ctx.Err() != nil {
+		log.Printf("Keygen session %s canceled: %v", sessionID, ctx.Err())
+		return nil, fmt.Errorf("keygen canceled: %w", ctx.Err())
+	}
+	if err != nil && waitCtx.Err() == nil {
+		log.Printf("Keygen session %s failed: %v", sessionID, err)
+		return nil, fmt.Errorf("failed to generate keys: %w", err)
+	}
+
+	// 모든 파티가 같은 공개키를 보고해야 키를 등록합니다.
+	publicKey, partyKeys, err := session.Agree(names, results)
+	if err != nil {
+		log.Printf("Keygen session %s: %v", sessionID, err)
+		return nil, err
+	}
+
+	// 서명 등에서 키를 찾을 수 있도록 키 메타데이터를 등록합니다.
+	err = keys.Put(&registry.Key{
+		ID:         keyID,
+		PublicKey:  publicKey,
+		Curve:      req.Curve,
+		Threshold:  req.N,
+		Parties:    parties,
+		Labels:     req.Labels,
+		Generation: sessionID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Printf("Keygen session %s: %v", sessionID, err)
+		return nil, err
+	}
+	registered = true
+	// 키 조각을 보관한 파티는 이 키의 서명과 재공유에만 사용합니다.
+	orch.Assign(keyID, names...)
+
+	return &KeygenResponse{KeyID: keyID, Curve: req.Curve, PublicKey: publicKey, Parties: partyKeys}, nil
+}
+
+// deleteShares는 실패한 키 생성에서 파티들이 저장했을 수 있는 키 조각을 지웁니다.
+// 아직 키 생성 중인 파티가 지운 뒤에 조각을 저장하지 않도록, 모든 파티의 키 생성 호출이 끝난 뒤 지웁니다.
+func deleteShares(calls *sync.WaitGroup, keyID string, workers []orchestrator.Worker) {
+	calls.Wait()
+	for _, worker := range workers {
+		if err := grpcClient.CallDeleteShare(worker.Address(), keyID); err != nil {
+			log.Printf("Failed to delete share of failed key %s on party %s: %v", keyID, worker.Name, err)
+		}
 	}
 }
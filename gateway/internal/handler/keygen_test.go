@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gateway/internal/config"
+	grpcClient "gateway/internal/grpc"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+	"gateway/internal/store"
+	"gateway/pkg/response"
+	"proto/tss/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeKeygenParty는 키 생성 요청에 publicKey를 보고하고(fail이면 실패하고) 받은 조각 삭제 요청을 기록하는 파티입니다.
+// release가 있으면 닫힐 때까지 응답하지 않습니다.
+type fakeKeygenParty struct {
+	tssv1.UnimplementedKeygenServiceServer
+	name      string
+	publicKey string
+	fail      bool
+	release   chan struct{}
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (p *fakeKeygenParty) GenerateKey(context.Context, *tssv1.KeygenRequest) (*tssv1.KeygenResponse, error) {
+	if p.release != nil {
+		<-p.release
+	}
+	if p.fail {
+		return nil, status.Error(codes.Internal, "keygen failed")
+	}
+	return &tssv1.KeygenResponse{Publickey: p.publicKey}, nil
+}
+
+func (p *fakeKeygenParty) DeleteShare(_ context.Context, req *tssv1.DeleteShareRequest) (*tssv1.DeleteShareResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deleted = append(p.deleted, req.KeyId)
+	return &tssv1.DeleteShareResponse{}, nil
+}
+
+func (p *fakeKeygenParty) deletedKeys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.deleted...)
+}
+
+// keygenOrchestrator는 워커를 만들거나 지우지 않고 풀만 제공합니다.
+type keygenOrchestrator struct {
+	*orchestrator.Pool
+}
+
+func (keygenOrchestrator) Start(context.Context) error { return nil }
+
+func (keygenOrchestrator) Create(context.Context, int) ([]orchestrator.Worker, error) {
+	return nil, errors.New("not supported")
+}
+
+func (keygenOrchestrator) Delete(context.Context, string) error { return nil }
+
+type keygenFixture struct {
+	server *grpcClient.KeygenServiceServer
+	keys   *registry.Registry
+	orch   keygenOrchestrator
+}
+
+// newKeygenFixture는 parties를 루프백 주소에서 실행하고 풀에 넣습니다.
+func newKeygenFixture(t *testing.T, parties ...*fakeKeygenParty) *keygenFixture {
+	t.Helper()
+	cfg := config.Get()
+	cfg.Routing.Mode = config.RoutingDirect
+	cfg.Pool.QueueTimeoutSeconds = 5
+	cfg.Session.TimeoutSeconds = 5
+
+	backend := store.NewMemory()
+	sessions, err := session.NewRegistry(time.Hour, backend)
+	if err != nil {
+		t.Fatalf("session.NewRegistry: %v", err)
+	}
+	keys, err := registry.New(backend)
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+	pool := orchestrator.NewPool(time.Minute, 10)
+	for _, party := range parties {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen: %v", err)
+		}
+		server := grpc.NewServer()
+		tssv1.RegisterKeygenServiceServer(server, party)
+		go server.Serve(lis)
+		t.Cleanup(server.Stop)
+		pool.Add(orchestrator.Worker{Name: party.name, IP: "127.0.0.1", Port: int32(lis.Addr().(*net.TCPAddr).Port)})
+	}
+	return &keygenFixture{server: grpcClient.NewKeygenServiceServer(sessions), keys: keys, orch: keygenOrchestrator{pool}}
+}
+
+func (f *keygenFixture) keygen(ctx context.Context, m int) (*KeygenResponse, error) {
+	req := KeygenRequest{N: 1, M: m, Curve: registry.CurveSecp256k1}
+	return keygen(ctx, f.server, nil, f.keys, f.orch, req, func(string) {}, func(string) {})
+}
+
+// waitDeleted는 모든 파티가 조각 삭제 요청을 받을 때까지 기다리고, 삭제한 키 ID를 반환합니다.
+func waitDeleted(t *testing.T, parties ...*fakeKeygenParty) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for _, party := range parties {
+		for len(party.deletedKeys()) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("party %s did not receive DeleteShare", party.name)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	keyID := parties[0].deletedKeys()[0]
+	for _, party := range parties {
+		if got := party.deletedKeys(); len(got) != 1 || got[0] != keyID {
+			t.Fatalf("party %s deleted %v, want [%s]", party.name, got, keyID)
+		}
+	}
+	return keyID
+}
+
+// 한 파티가 실패하거나 파티들의 공개키가 다르면, 조각을 저장했을 수 있는 모든 파티의 조각을 지웁니다.
+func TestKeygenDeletesSharesOnFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		parties []*fakeKeygenParty
+	}{
+		{"party failed", []*fakeKeygenParty{
+			{name: "party-a", publicKey: "02aa"},
+			{name: "party-b", publicKey: "02aa"},
+			{name: "party-c", fail: true},
+		}},
+		{"public keys disagree", []*fakeKeygenParty{
+			{name: "party-a", publicKey: "02aa"},
+			{name: "party-b", publicKey: "02aa"},
+			{name: "party-c", publicKey: "02bb"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newKeygenFixture(t, tt.parties...)
+			if _, err := f.keygen(context.Background(), len(tt.parties)); err == nil {
+				t.Fatal("keygen succeeded")
+			}
+			keyID := waitDeleted(t, tt.parties...)
+			if _, ok := f.keys.Get(keyID); ok {
+				t.Fatalf("failed key %s was registered", keyID)
+			}
+			for _, party := range tt.parties {
+				if f.orch.Assigned(party.name) {
+					t.Fatalf("party %s is assigned to the failed key", party.name)
+				}
+			}
+		})
+	}
+}
+
+// 먼저 실패한 파티가 있어도, 아직 키 생성 중인 파티는 호출이 끝난 뒤 조각을 지웁니다.
+func TestKeygenDeletesSharesAfterSlowParties(t *testing.T) {
+	slow := &fakeKeygenParty{name: "party-b", publicKey: "02aa", release: make(chan struct{})}
+	parties := []*fakeKeygenParty{{name: "party-a", fail: true}, slow}
+	f := newKeygenFixture(t, parties...)
+
+	if _, err := f.keygen(context.Background(), 2); err == nil {
+		t.Fatal("keygen succeeded")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := slow.deletedKeys(); len(got) != 0 {
+		t.Fatalf("share of %s was deleted while its keygen was running", slow.name)
+	}
+	close(slow.release)
+	waitDeleted(t, parties...)
+}
+
+func TestKeygenKeepsSharesOnSuccess(t *testing.T) {
+	parties := []*fakeKeygenParty{{name: "party-a", publicKey: "02aa"}, {name: "party-b", publicKey: "02aa"}}
+	f := newKeygenFixture(t, parties...)
+
+	resp, err := f.keygen(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+	if _, ok := f.keys.Get(resp.KeyID); !ok {
+		t.Fatalf("key %s was not registered", resp.KeyID)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, party := range parties {
+		if got := party.deletedKeys(); len(got) != 0 {
+			t.Fatalf("party %s deleted %v after a successful keygen", party.name, got)
+		}
+		if !f.orch.Assigned(party.name) {
+			t.Fatalf("party %s is not assigned to the new key", party.name)
+		}
+	}
+}
+
+// 키 생성 중에 요청이 취소되면 합의 실패가 아닌 취소로 실패하고, 파티들의 조각을 지웁니다.
+func TestKeygenCanceled(t *testing.T) {
+	release := make(chan struct{})
+	parties := []*fakeKeygenParty{
+		{name: "party-a", publicKey: "02aa", release: release},
+		{name: "party-b", publicKey: "02aa", release: release},
+	}
+	f := newKeygenFixture(t, parties...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := f.keygen(ctx, 2)
+	var agreementErr *session.AgreementError
+	if !errors.Is(err, context.Canceled) || errors.As(err, &agreementErr) {
+		t.Fatalf("keygen returned %v, want a cancellation error", err)
+	}
+	close(release)
+	waitDeleted(t, parties...)
+}
+
+func TestKeygenHandlerCanceled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newKeygenFixture(t, &fakeKeygenParty{name: "party-a"}, &fakeKeygenParty{name: "party-b"})
+	router := gin.New()
+	router.POST("/keygen", Keygen(f.server, nil, f.keys, nil, f.orch))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/keygen", strings.NewReader(`{"n": 1, "m": 2}`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != response.StatusClientClosedRequest || !strings.Contains(rec.Body.String(), response.ErrRequestCanceled) {
+		t.Fatalf("canceled keygen responded %d %s, want %d %s", rec.Code, rec.Body.String(), response.StatusClientClosedRequest, response.ErrRequestCanceled)
+	}
+}
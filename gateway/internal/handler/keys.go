@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gateway/internal/registry"
+	"gateway/pkg/response"
+)
+
+// 키 목록 한 페이지의 기본 크기와 최대 크기
+const (
+	defaultKeyPageSize = 100
+	maxKeyPageSize     = 1000
+)
+
+// ListKeysRequest의 Label은 "이름=값" 형식이며, 여러 개를 주면 모두 만족하는 키만 반환합니다.
+type ListKeysRequest struct {
+	Label  []string `form:"label"`
+	Limit  int      `form:"limit"`
+	Offset int      `form:"offset"`
+}
+
+// ListKeysResponse의 NextOffset은 다음 페이지의 offset이며, 마지막 페이지이면 비어 있습니다.
+type ListKeysResponse struct {
+	Keys       []*registry.Key `json:"keys"`
+	Total      int             `json:"total"`
+	NextOffset *int            `json:"next_offset,omitempty"`
+}
+
+// ListKeys는 등록된 키를 생성 시각 순서로 반환합니다.
+// label로 레이블을 거르고, limit과 offset으로 페이지를 나눕니다.
+func ListKeys(keys *registry.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ListKeysRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			sendError(c, response.ErrInvalidKeyQuery, err.Error())
+			return
+		}
+		if req.Limit == 0 {
+			req.Limit = defaultKeyPageSize
+		}
+		if req.Limit < 0 || req.Limit > maxKeyPageSize || req.Offset < 0 {
+			sendError(c, response.ErrInvalidKeyQuery, "limit은 1 이상 1000 이하, offset은 0 이상이어야 합니다")
+			return
+		}
+		selector, err := registry.ParseSelector(req.Label)
+		if err != nil {
+			sendError(c, response.ErrInvalidKeyQuery, err.Error())
+			return
+		}
+
+		matched := keys.List(selector)
+		resp := ListKeysResponse{Keys: []*registry.Key{}, Total: len(matched)}
+		if req.Offset < len(matched) {
+			end := req.Offset + req.Limit
+			if end < len(matched) {
+				resp.NextOffset = &end
+			} else {
+				end = len(matched)
+			}
+			resp.Keys = matched[req.Offset:end]
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// GetKey는 키의 메타데이터를 반환합니다. 마지막 조각 갱신 시각(refreshed_at)도 함께 반환합니다.
+func GetKey(keys *registry.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := keys.Get(c.Param("id"))
+		if !ok {
+			sendError(c, response.ErrKeyNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, key)
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/registry"
+	"gateway/internal/store"
+	"gateway/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newKeysRouter는 key-0부터 key-(n-1)까지 생성 순서대로 등록한 레지스트리로 ListKeys를 제공합니다.
+// 짝수 키에는 env=prod, 홀수 키에는 env=dev 레이블이 붙고, 세 번째 키마다 team=wallet 레이블이 붙습니다.
+func newKeysRouter(t *testing.T, n int) *gin.Engine {
+	t.Helper()
+	keys, err := registry.New(store.NewMemory())
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		labels := map[string]string{"env": "dev"}
+		if i%2 == 0 {
+			labels["env"] = "prod"
+		}
+		if i%3 == 0 {
+			labels["team"] = "wallet"
+		}
+		key := &registry.Key{ID: fmt.Sprintf("key-%d", i), Curve: registry.CurveSecp256k1, Labels: labels, CreatedAt: created.Add(time.Duration(i) * time.Minute)}
+		if err := keys.Put(key); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/keys", ListKeys(keys))
+	return router
+}
+
+func listKeys(t *testing.T, router *gin.Engine, query string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/keys"+query, nil))
+	return rec.Code, rec.Body.String()
+}
+
+// keyIDs는 응답의 키 ID를 쉼표로 이어 붙입니다.
+func keyIDs(resp ListKeysResponse) string {
+	ids := make([]string, len(resp.Keys))
+	for i, key := range resp.Keys {
+		ids[i] = key.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestListKeys(t *testing.T) {
+	router := newKeysRouter(t, 7)
+	next := func(n int) *int { return &n }
+
+	tests := []struct {
+		name  string
+		query string
+		keys  string
+		total int
+		next  *int
+	}{
+		{"all keys", "", "key-0,key-1,key-2,key-3,key-4,key-5,key-6", 7, nil},
+		{"label", "?label=env=prod", "key-0,key-2,key-4,key-6", 4, nil},
+		{"all labels must match", "?label=env=prod&label=team=wallet", "key-0,key-6", 2, nil},
+		{"no match", "?label=env=staging", "", 0, nil},
+		{"first page", "?limit=3", "key-0,key-1,key-2", 7, next(3)},
+		{"middle page", "?limit=3&offset=3", "key-3,key-4,key-5", 7, next(6)},
+		{"last page", "?limit=3&offset=6", "key-6", 7, nil},
+		{"page ends at the last key", "?limit=2&offset=5", "key-5,key-6", 7, nil},
+		{"offset past the end", "?offset=10", "", 7, nil},
+		{"labelled page", "?label=env=dev&limit=2", "key-1,key-3", 3, next(2)},
+		{"labelled last page", "?label=env=dev&limit=2&offset=2", "key-5", 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := listKeys(t, router, tt.query)
+			if code != http.StatusOK {
+				t.Fatalf("ListKeys responded %d %s", code, body)
+			}
+			var resp ListKeysResponse
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			// 결과가 없어도 keys는 null이 아닌 빈 배열입니다.
+			if resp.Keys == nil {
+				t.Fatalf("keys is null: %s", body)
+			}
+			if got := keyIDs(resp); got != tt.keys {
+				t.Fatalf("keys = [%s], want [%s]", got, tt.keys)
+			}
+			if resp.Total != tt.total {
+				t.Fatalf("total = %d, want %d", resp.Total, tt.total)
+			}
+			switch {
+			case tt.next == nil && resp.NextOffset != nil:
+				t.Fatalf("next_offset = %d, want none", *resp.NextOffset)
+			case tt.next != nil && (resp.NextOffset == nil || *resp.NextOffset != *tt.next):
+				t.Fatalf("next_offset = %v, want %d", resp.NextOffset, *tt.next)
+			}
+		})
+	}
+}
+
+func TestListKeysRejectsInvalidQuery(t *testing.T) {
+	router := newKeysRouter(t, 1)
+	for _, query := range []string{
+		"?limit=-1",
+		"?limit=1001",
+		"?limit=ten",
+		"?offset=-1",
+		"?offset=one",
+		"?label=env",
+		"?label==prod",
+		"?label=env=prod!",
+	} {
+		t.Run(query, func(t *testing.T) {
+			code, body := listKeys(t, router, query)
+			if code != http.StatusBadRequest || !strings.Contains(body, response.ErrInvalidKeyQuery) {
+				t.Fatalf("ListKeys%s responded %d %s, want %d %s", query, code, body, http.StatusBadRequest, response.ErrInvalidKeyQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gateway/internal/autoscale"
+	"gateway/internal/config"
+	"gateway/internal/orchestrator"
+)
+
+// PoolStats는 대기 풀의 상태별 파티 수와 파티를 기다리는 요청 수를 반환합니다.
+func PoolStats(orch orchestrator.Orchestrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, orch.Stats())
+	}
+}
+
+// AutoscaleStatus는 오토스케일러의 목표 파티 수와 실제 파티 수를 반환합니다.
+// 오토스케일러가 꺼져 있으면 실제 파티 수만 반환합니다.
+func AutoscaleStatus(orch orchestrator.Orchestrator, scaler *autoscale.Scaler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scaler == nil {
+			c.JSON(http.StatusOK, autoscale.Status{Actual: orch.Stats()})
+			return
+		}
+		c.JSON(http.StatusOK, scaler.Status())
+	}
+}
+
+// waitForParties는 대기 풀에서 파티를 빌립니다. 파티가 부족하면 설정된 시간까지 대기열에서 기다립니다.
+func waitForParties(ctx context.Context, orch orchestrator.Orchestrator, req orchestrator.Request) ([]orchestrator.Worker, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.Get().Pool.QueueTimeoutSeconds)*time.Second)
+	defer cancel()
+	return orch.Wait(ctx, req)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gateway/internal/registry"
+	"gateway/internal/reshare"
+	"gateway/internal/session"
+	"gateway/pkg/response"
+)
+
+// ReshareRequest의 N은 새 임계값(t), M은 새 위원회의 파티 수입니다.
+type ReshareRequest struct {
+	N int `json:"n" binding:"required"`
+	M int `json:"m" binding:"required"`
+}
+
+type ReshareResponse struct {
+	KeyID     string           `json:"key_id"`
+	PublicKey string           `json:"publickey"`
+	Threshold int              `json:"threshold"`
+	Parties   []registry.Party `json:"parties"`
+}
+
+// Reshare는 키의 공개키를 유지한 채 키 조각을 대기 풀에서 고른 새 위원회로 옮깁니다.
+// 기존 위원회는 키 메타데이터의 파티들이며, 성공하면 새 위원회에 없는 파티의 조각을 삭제합니다.
+func Reshare(keys *registry.Registry, reshares *reshare.Coordinator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ReshareRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			sendError(c, response.ErrInvalidReshareRequest, err.Error())
+			return
+		}
+		if req.N < 1 || req.N >= req.M {
+			sendError(c, response.ErrInvalidReshareRequest, "n은 1 이상 m 미만이어야 합니다")
+			return
+		}
+
+		if _, ok := keys.Get(c.Param("id")); !ok {
+			sendError(c, response.ErrKeyNotFound)
+			return
+		}
+
+		result, err := reshares.Reshare(c.Param("id"), req.M, req.N)
+		var agreementErr *session.AgreementError
+		code, capacityErr := capacityErrorCode(err)
+		switch {
+		case err == nil:
+		case errors.Is(err, reshare.ErrKeyNotFound):
+			sendError(c, response.ErrKeyNotFound)
+			return
+		case errors.Is(err, reshare.ErrKeyBusy):
+			sendError(c, response.ErrKeyBusy)
+			return
+		case capacityErr:
+			sendError(c, code, err.Error())
+			return
+		case errors.As(err, &agreementErr):
+			resp := response.NewErrorResponse(response.ErrKeyAgreement, err.Error())
+			c.JSON(resp.StatusCode, keyAgreementResponse{ErrorResponse: resp, Parties: agreementErr.Parties})
+			return
+		default:
+			sendError(c, response.ErrResharing, err.Error())
+			return
+		}
+
+		c.JSON(http.StatusOK, ReshareResponse{
+			KeyID:     result.Key.ID,
+			PublicKey: result.Key.PublicKey,
+			Threshold: result.Key.Threshold,
+			Parties:   result.Key.Parties,
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	grpcClient "gateway/internal/grpc"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/pkg/response"
+	"proto/tss/v1"
+)
+
+// SignRequest의 Priority는 파티가 부족할 때 대기열에서의 우선순위(low, normal, high)이며 기본값은 normal입니다.
+type SignRequest struct {
+	KeyID       string `json:"key_id" binding:"required"`
+	MessageHash string `json:"message_hash" binding:"required"`
+	Priority    string `json:"priority"`
+}
+
+// SignResponse의 Signature는 r과 s를 이어 붙인 64바이트 서명입니다.
+// RecoveryID는 secp256k1 키에서만 의미가 있습니다.
+type SignResponse struct {
+	R          string `json:"r"`
+	S          string `json:"s"`
+	RecoveryID int32  `json:"recovery_id"`
+	Signature  string `json:"signature"`
+}
+
+// Sign은 키 조각을 가진 파티 중 t+1개를 골라 메시지 해시에 대한 서명을 만듭니다.
+func Sign(keys *registry.Registry, relayServer *grpcClient.RelayServer, orch orchestrator.Orchestrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SignRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			sendError(c, response.ErrInvalidSignRequest, err.Error())
+			return
+		}
+
+		hash, err := hex.DecodeString(strings.TrimPrefix(req.MessageHash, "0x"))
+		if err != nil || len(hash) != 32 {
+			sendError(c, response.ErrInvalidSignRequest, "message_hash는 32바이트 hex 문자열이어야 합니다")
+			return
+		}
+		priority, err := orchestrator.ParsePriority(req.Priority)
+		if err != nil {
+			sendError(c, response.ErrInvalidSignRequest, err.Error())
+			return
+		}
+
+		key, ok := keys.Get(req.KeyID)
+		if !ok {
+			sendError(c, response.ErrKeyNotFound)
+			return
+		}
+
+		// 키 조각을 가진 파티 중 다른 세션이 사용하지 않는 t+1개를 빌려 서명에 참여시킵니다.
+		// 모두 사용 중이면 대기열에서 기다립니다.
+		sessionID := uuid.NewString()
+		holders := make([]string, len(key.Parties))
+		for i, party := range key.Parties {
+			holders[i] = party.Name
+		}
+		workers, err := waitForParties(c.Request.Context(), orch, orchestrator.Request{
+			Owner:      sessionID,
+			N:          key.Threshold + 1,
+			Candidates: holders,
+			Priority:   priority,
+		})
+		if err != nil {
+			if code, ok := capacityErrorCode(err); ok {
+				sendError(c, code, err.Error())
+			} else {
+				sendError(c, response.ErrSigning, err.Error())
+			}
+			return
+		}
+		defer orch.Release(sessionID)
+
+		// 파티를 기다리는 동안 재공유가 끝났을 수 있으므로 키를 다시 읽고, 빌린 파티가 지금 세대의 서명자인지 확인합니다.
+		key, ok = keys.Get(req.KeyID)
+		if !ok {
+			sendError(c, response.ErrKeyNotFound)
+			return
+		}
+		if len(workers) != key.Threshold+1 {
+			sendError(c, response.ErrKeyBusy, "파티를 기다리는 동안 키가 재공유되었습니다. 다시 요청하세요")
+			return
+		}
+		for _, worker := range workers {
+			if _, ok := key.Party(worker.Name); !ok {
+				sendError(c, response.ErrKeyBusy, "파티를 기다리는 동안 키가 재공유되었습니다. 다시 요청하세요")
+				return
+			}
+		}
+
+		// 주소는 파티가 다시 시작되며 바뀌었을 수 있으므로 풀의 주소를 사용합니다.
+		signers := make([]registry.Party, len(workers))
+		podInfos := make([]*tssv1.PodInfo, len(workers))
+		names := make([]string, len(workers))
+		for i, worker := range workers {
+			party, _ := key.Party(worker.Name)
+			party.IP, party.Port = worker.IP, worker.Port
+			signers[i] = party
+			podInfos[i] = grpcClient.PodInfoOf(party)
+			names[i] = worker.Name
+		}
+
+		tokens, closeRelay, err := grpcClient.OpenRelaySession(relayServer, sessionID, names)
+		if err != nil {
+			sendError(c, response.ErrSigning, err.Error())
+			return
+		}
+		defer closeRelay()
+		grpcClient.SetTokenHashes(tokens, podInfos)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var signature *tssv1.SignResponse
+		var errs []string
+
+		for _, party := range signers {
+			wg.Add(1)
+			go func(party registry.Party) {
+				defer wg.Done()
+				address := fmt.Sprintf("%s:%d", party.IP, party.Port)
+				resp, err := grpcClient.CallSignService(address, sessionID, tokens[party.Name], key.ID, key.Generation, hash, podInfos)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					log.Printf("Failed to call sign service on party %s: %v", party.Name, err)
+					errs = append(errs, fmt.Sprintf("%s: %v", party.Name, err))
+					return
+				}
+				signature = resp
+			}(party)
+		}
+
+		wg.Wait()
+
+		// 서명은 모든 참여 파티가 성공해야 유효합니다.
+		if len(errs) > 0 || signature == nil {
+			sendError(c, response.ErrSigning, strings.Join(errs, "; "))
+			return
+		}
+
+		// 파티가 돌려준 서명이 등록된 공개키로 검증되어야 응답합니다.
+		if err := verifySignature(key, hash, signature); err != nil {
+			log.Printf("Sign session %s returned an invalid signature for key %s: %v", sessionID, key.ID, err)
+			sendError(c, response.ErrSigning, err.Error())
+			return
+		}
+
+		c.JSON(http.StatusOK, SignResponse{
+			R:          hex.EncodeToString(signature.R),
+			S:          hex.EncodeToString(signature.S),
+			RecoveryID: signature.RecoveryId,
+			Signature:  hex.EncodeToString(signature.Signature),
+		})
+	}
+}
+
+// verifySignature는 서명이 키의 공개키로 hash에 대해 유효한지 확인합니다.
+// secp256k1 키는 (r, s, v)로 복구한 공개키가 등록된 공개키와 같아야 합니다.
+func verifySignature(key *registry.Key, hash []byte, signature *tssv1.SignResponse) error {
+	publicKey, err := hex.DecodeString(key.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key of key %s: %v", key.ID, err)
+	}
+
+	switch key.Curve {
+	case registry.CurveEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid ed25519 public key length %d", len(publicKey))
+		}
+		if !ed25519.Verify(publicKey, hash, signature.Signature) {
+			return errors.New("signature does not verify against the public key")
+		}
+		return nil
+	case registry.CurveSecp256k1:
+		pub, err := secp256k1.ParsePubKey(publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid secp256k1 public key: %v", err)
+		}
+		if len(signature.R) > 32 || len(signature.S) > 32 || signature.RecoveryId < 0 || signature.RecoveryId > 3 {
+			return errors.New("malformed signature")
+		}
+		// 압축 공개키 형식의 compact 서명: 27 + 4 + v, 32바이트 r, 32바이트 s
+		compact := make([]byte, 65)
+		compact[0] = byte(27 + 4 + signature.RecoveryId)
+		copy(compact[33-len(signature.R):33], signature.R)
+		copy(compact[65-len(signature.S):], signature.S)
+		recovered, _, err := ecdsa.RecoverCompact(compact, hash)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %v", err)
+		}
+		if !recovered.IsEqual(pub) {
+			return errors.New("signature does not recover the public key")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported curve: %s", key.Curve)
+	}
+}
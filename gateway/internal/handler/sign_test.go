@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"gateway/internal/registry"
+	"proto/tss/v1"
+)
+
+func TestVerifySignatureSecp256k1(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	key := &registry.Key{ID: "key-1", Curve: registry.CurveSecp256k1, PublicKey: hex.EncodeToString(priv.PubKey().SerializeCompressed())}
+	hash := sha256.Sum256([]byte("message"))
+
+	// compact 서명은 27 + 4 + v, r, s 순서입니다.
+	compact := ecdsa.SignCompact(priv, hash[:], true)
+	signature := &tssv1.SignResponse{R: compact[1:33], S: compact[33:], RecoveryId: int32(compact[0] - 27 - 4)}
+	if err := verifySignature(key, hash[:], signature); err != nil {
+		t.Fatalf("verifySignature: %v", err)
+	}
+
+	wrongV := &tssv1.SignResponse{R: signature.R, S: signature.S, RecoveryId: signature.RecoveryId ^ 1}
+	if err := verifySignature(key, hash[:], wrongV); err == nil {
+		t.Fatal("verifySignature accepted a signature with the wrong recovery id")
+	}
+	other := sha256.Sum256([]byte("other message"))
+	if err := verifySignature(key, other[:], signature); err == nil {
+		t.Fatal("verifySignature accepted a signature over another hash")
+	}
+}
+
+func TestVerifySignatureEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	key := &registry.Key{ID: "key-1", Curve: registry.CurveEd25519, PublicKey: hex.EncodeToString(pub)}
+	hash := sha256.Sum256([]byte("message"))
+
+	signature := &tssv1.SignResponse{Signature: ed25519.Sign(priv, hash[:])}
+	if err := verifySignature(key, hash[:], signature); err != nil {
+		t.Fatalf("verifySignature: %v", err)
+	}
+
+	other := sha256.Sum256([]byte("other message"))
+	if err := verifySignature(key, other[:], signature); err == nil {
+		t.Fatal("verifySignature accepted a signature over another hash")
+	}
+}
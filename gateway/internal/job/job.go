@@ -0,0 +1,165 @@
+package job
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gateway/internal/session"
+	"gateway/pkg/response"
+
+	"github.com/google/uuid"
+)
+
+// 작업 상태
+const (
+	StatePending   = "pending"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+)
+
+// Job은 비동기로 실행되는 키 생성 작업입니다.
+// SessionID는 작업이 파티를 기다릴 때 대기열 순서를, 실행 중일 때 진행 라운드를 찾는 데 사용합니다.
+type Job struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	SessionID string `json:"-"`
+	// QueuePosition은 파티를 기다리는 작업의 대기열 순서(1부터)로, 조회할 때 채웁니다.
+	QueuePosition int    `json:"queue_position,omitempty"`
+	Round         int    `json:"round,omitempty"`
+	KeyID         string `json:"key_id,omitempty"`
+	Curve         string `json:"curve,omitempty"`
+	PublicKey     string `json:"publickey,omitempty"`
+	ErrorCode     string `json:"error_code,omitempty"`
+	Error         string `json:"error,omitempty"`
+
+	// Parties는 각 파티가 보고한 공개키 지문입니다. 키 생성이 끝난 뒤에 채워집니다.
+	Parties []session.PartyKey `json:"parties,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Backend는 작업을 영구 저장합니다.
+type Backend interface {
+	PutJob(job Job) error
+	DeleteJob(id string) error
+	ListJobs() ([]Job, error)
+}
+
+// Store는 작업을 작업 ID로 보관합니다. 조회 결과는 복사본이므로 호출한 쪽에서 고쳐도 안전합니다.
+// 모든 변경은 backend에 함께 저장합니다. 끝난 작업은 마지막으로 바뀐 뒤 retention이 지나면 지웁니다.
+type Store struct {
+	backend   Backend
+	retention time.Duration
+
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewStore는 backend에 저장된 작업을 불러옵니다. 끝나지 않은 작업은 재시작으로 중단되었으므로 실패로 바꿉니다.
+func NewStore(backend Backend, retention time.Duration) (*Store, error) {
+	jobs, err := backend.ListJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jobs: %v", err)
+	}
+
+	s := &Store{
+		backend:   backend,
+		retention: retention,
+		jobs:      make(map[string]*Job, len(jobs)),
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if job.State == StatePending || job.State == StateRunning {
+			job.State = StateFailed
+			job.ErrorCode = response.ErrJobInterrupted
+			job.Error = "gateway restarted before the job finished"
+			job.UpdatedAt = time.Now()
+			if err := backend.PutJob(*job); err != nil {
+				return nil, fmt.Errorf("failed to update interrupted job %s: %v", job.ID, err)
+			}
+		}
+		s.jobs[job.ID] = job
+	}
+	s.evict(time.Now())
+	go s.expire()
+	return s, nil
+}
+
+// Create는 pending 상태의 새 작업을 만듭니다.
+func (s *Store) Create() Job {
+	now := time.Now()
+	job := &Job{
+		ID:        uuid.NewString(),
+		State:     StatePending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	s.save(job)
+	return *job
+}
+
+func (s *Store) Get(id string) (Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// Update는 작업을 fn으로 고치고 갱신 시각을 기록합니다.
+func (s *Store) Update(id string, fn func(job *Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[id]; ok {
+		fn(job)
+		job.UpdatedAt = time.Now()
+		s.save(job)
+	}
+}
+
+// save는 s.mu를 잡은 상태에서 호출해야 합니다. 작업 상태는 키 생성 결과를 알려 주는 용도이므로
+// 저장에 실패해도 작업은 계속 진행합니다.
+func (s *Store) save(job *Job) {
+	if err := s.backend.PutJob(*job); err != nil {
+		log.Printf("Failed to save job %s: %v", job.ID, err)
+	}
+}
+
+func (s *Store) expire() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.evict(now)
+	}
+}
+
+// evict는 now 기준으로 retention보다 오래전에 끝난 작업을 지웁니다. 진행 중인 작업은 지우지 않습니다.
+func (s *Store) evict(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, job := range s.jobs {
+		if job.State != StateSucceeded && job.State != StateFailed {
+			continue
+		}
+		if now.Sub(job.UpdatedAt) <= s.retention {
+			continue
+		}
+		// 저장소에서 지우지 못한 작업은 다음 주기에 다시 지웁니다.
+		if err := s.backend.DeleteJob(id); err != nil {
+			log.Printf("Failed to delete expired job %s: %v", id, err)
+			continue
+		}
+		delete(s.jobs, id)
+	}
+}
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+// memoryBackend는 작업을 맵에 저장하는 테스트용 Backend입니다.
+type memoryBackend map[string]Job
+
+func (b memoryBackend) PutJob(job Job) error {
+	b[job.ID] = job
+	return nil
+}
+
+func (b memoryBackend) DeleteJob(id string) error {
+	delete(b, id)
+	return nil
+}
+
+func (b memoryBackend) ListJobs() ([]Job, error) {
+	jobs := make([]Job, 0, len(b))
+	for _, job := range b {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func TestStoreEvictsFinishedJobsAfterRetention(t *testing.T) {
+	backend := memoryBackend{}
+	s, err := NewStore(backend, time.Hour)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	succeeded := s.Create()
+	s.Update(succeeded.ID, func(job *Job) { job.State = StateSucceeded })
+	failed := s.Create()
+	s.Update(failed.ID, func(job *Job) { job.State = StateFailed })
+	running := s.Create()
+	s.Update(running.ID, func(job *Job) { job.State = StateRunning })
+
+	s.evict(time.Now().Add(30 * time.Minute))
+	if _, ok := s.Get(succeeded.ID); !ok {
+		t.Fatal("job was evicted before its retention ended")
+	}
+
+	s.evict(time.Now().Add(2 * time.Hour))
+	for _, id := range []string{succeeded.ID, failed.ID} {
+		if _, ok := s.Get(id); ok {
+			t.Fatalf("finished job %s was not evicted", id)
+		}
+		if _, ok := backend[id]; ok {
+			t.Fatalf("finished job %s remains in the backend", id)
+		}
+	}
+	// 진행 중인 작업은 오래되어도 지우지 않습니다.
+	if _, ok := s.Get(running.ID); !ok {
+		t.Fatal("running job was evicted")
+	}
+}
+
+// 재시작 전에 끝나 retention이 지난 작업은 불러올 때 지웁니다.
+func TestNewStoreEvictsExpiredJobs(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	backend := memoryBackend{
+		"old":    {ID: "old", State: StateSucceeded, CreatedAt: old, UpdatedAt: old},
+		"recent": {ID: "recent", State: StateFailed, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	}
+	s, err := NewStore(backend, time.Hour)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if _, ok := s.Get("old"); ok {
+		t.Fatal("expired job was loaded")
+	}
+	if _, ok := backend["old"]; ok {
+		t.Fatal("expired job remains in the backend")
+	}
+	if _, ok := s.Get("recent"); !ok {
+		t.Fatal("recent job was not loaded")
+	}
+}
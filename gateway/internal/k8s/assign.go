@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"gateway/internal/config"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// keysAnnotation은 파티가 조각을 보관한 키의 ID 목록(쉼표로 구분)입니다.
+// 풀의 키 배정은 게이트웨이 메모리에 있으므로, 여러 게이트웨이가 같은 Pod을 사용할 때 다른 게이트웨이가 만든 키의 조각을 보관한 파티를
+// 새 키 생성에 빌려주거나 오토스케일러가 지우지 않도록 배정을 Pod에 기록합니다. 인포머는 이 어노테이션으로 풀의 배정을 맞춥니다.
+const keysAnnotation = "tss.bnb-chain/keys"
+
+// keysOf는 Pod에 기록된 키 ID를 반환합니다.
+func keysOf(pod *corev1.Pod) []string {
+	value := pod.Annotations[keysAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// Assign은 파티들이 keyID의 조각을 보관한다고 Pod에 기록한 뒤 풀에 배정합니다.
+// Start 전에는 풀에만 배정하고, Start가 Pod에 한꺼번에 기록합니다(backfillKeys).
+func (o *Orchestrator) Assign(keyID string, names ...string) {
+	if o.informer != nil {
+		for _, name := range names {
+			o.annotateKeys(context.Background(), o.podName(name), func(keys []string) []string {
+				if slices.Contains(keys, keyID) {
+					return keys
+				}
+				return append(keys, keyID)
+			})
+		}
+	}
+	o.Pool.Assign(keyID, names...)
+}
+
+// Unassign은 파티들이 더 이상 keyID의 조각을 보관하지 않는다고 Pod에 기록한 뒤 풀의 배정을 지웁니다.
+func (o *Orchestrator) Unassign(keyID string, names ...string) {
+	if o.informer != nil {
+		for _, name := range names {
+			o.annotateKeys(context.Background(), o.podName(name), func(keys []string) []string {
+				return slices.DeleteFunc(keys, func(k string) bool { return k == keyID })
+			})
+		}
+	}
+	o.Pool.Unassign(keyID, names...)
+}
+
+// annotateKeys는 Pod의 키 ID 목록을 update의 결과로 바꿉니다. 다른 게이트웨이가 동시에 바꾸면 다시 읽어 적용합니다.
+// Pod이 없으면 아무것도 하지 않습니다. 다시 만드는 Pod에는 풀의 배정이 기록됩니다.
+func (o *Orchestrator) annotateKeys(ctx context.Context, name string, update func(keys []string) []string) error {
+	pods := o.clientset.CoreV1().Pods(config.Get().Kubernetes.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		keys := update(keysOf(pod))
+		sort.Strings(keys)
+		value := strings.Join(keys, ",")
+		if value == pod.Annotations[keysAnnotation] {
+			return nil
+		}
+		setKeys(pod, keys)
+		_, err = pods.Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Failed to record key shares on pod %s: %v", name, err)
+	}
+	return err
+}
+
+// setKeys는 Pod의 키 ID 목록 어노테이션을 keys로 바꿉니다.
+func setKeys(pod *corev1.Pod, keys []string) {
+	if len(keys) == 0 {
+		delete(pod.Annotations, keysAnnotation)
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[keysAnnotation] = strings.Join(keys, ",")
+}
+
+// backfillKeys는 Start 전에 풀에 배정된 키(게이트웨이의 키 레지스트리)를 Pod에 기록합니다.
+// 인포머가 Pod의 어노테이션으로 풀의 배정을 맞추기 전에 호출해야, 어노테이션이 없는 Pod(이전 버전이 만든 Pod)의 배정을 잃지 않습니다.
+func (o *Orchestrator) backfillKeys(ctx context.Context) error {
+	list, err := o.clientset.CoreV1().Pods(config.Get().Kubernetes.Namespace).List(ctx, metav1.ListOptions{LabelSelector: partyLabelSelector})
+	if err != nil {
+		return fmt.Errorf("failed to list party pods: %v", err)
+	}
+	for i := range list.Items {
+		pod := &list.Items[i]
+		assigned := o.Keys(partyOf(pod))
+		if len(assigned) == 0 {
+			continue
+		}
+		err := o.annotateKeys(ctx, pod.Name, func(keys []string) []string {
+			for _, keyID := range assigned {
+				if !slices.Contains(keys, keyID) {
+					keys = append(keys, keyID)
+				}
+			}
+			return keys
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record key shares on pod %s: %v", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// holdsKeys는 Pod에 키 조각을 보관한다고 기록되어 있는지 API 서버에서 다시 확인합니다.
+// 다른 게이트웨이가 방금 배정한 파티를 지우지 않도록 Delete 전에 사용합니다.
+func (o *Orchestrator) holdsKeys(ctx context.Context, name string) (bool, error) {
+	pod, err := o.clientset.CoreV1().Pods(config.Get().Kubernetes.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(keysOf(pod)) > 0, nil
+}
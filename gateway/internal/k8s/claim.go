@@ -0,0 +1,395 @@
+package k8s
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// 파티 Pod을 점유한 게이트웨이와 세션을 기록하는 어노테이션
+const (
+	claimedByAnnotation = "tss.bnb-chain/claimed-by"
+	sessionAnnotation   = "tss.bnb-chain/session"
+)
+
+// 게이트웨이 Lease. 게이트웨이는 살아 있는 동안 자신의 Lease를 갱신하고,
+// Lease가 없거나 만료된 게이트웨이의 점유는 다른 게이트웨이가 풀어 줍니다.
+const (
+	gatewayLabel         = "app"
+	gatewayLabelValue    = "tss-gateway"
+	gatewayLabelSelector = gatewayLabel + "=" + gatewayLabelValue
+
+	leaseDuration = 30 * time.Second
+	renewInterval = 10 * time.Second
+)
+
+// claimedError는 Pod이 살아 있는 다른 게이트웨이에 점유되어 있어 점유하지 못했을 때의 에러입니다.
+type claimedError struct {
+	pod    string
+	holder string
+}
+
+func (e *claimedError) Error() string {
+	return fmt.Sprintf("pod %s is claimed by gateway %s", e.pod, e.holder)
+}
+
+// claimer는 여러 게이트웨이 프로세스가 같은 파티 Pod을 동시에 빌리지 않도록 Pod을 점유합니다.
+// 키 레지스트리, 세션, 작업은 게이트웨이 프로세스에만 있고 파티는 Service 하나로 게이트웨이에 접속하므로 게이트웨이는 복제본 하나로만
+// 실행합니다(start가 확인합니다). 점유는 재시작이나 교체로 두 프로세스가 잠깐 겹칠 때 같은 Pod을 빌리지 않게 합니다.
+//
+// 게이트웨이는 Pod을 빌릴 때 Pod에 자신의 ID와 세션 ID를 어노테이션으로 쓰며, resourceVersion을 함께 보내
+// 그 사이에 다른 게이트웨이가 Pod을 바꿨다면 충돌로 실패합니다(낙관적 동시성). 세션이 끝나면 어노테이션을 지웁니다.
+// 각 게이트웨이는 coordination.k8s.io Lease로 살아 있음을 알리고, Lease가 만료된 게이트웨이의 점유는 무효로 봅니다.
+type claimer struct {
+	clientset kubernetes.Interface
+	namespace string
+	id        string
+	// held는 이 게이트웨이에서 session이 Pod을 빌리고 있는지 확인합니다.
+	// 풀의 임대가 만료되어 어노테이션만 남은 Pod의 점유를 풀 때 사용합니다.
+	held func(pod *corev1.Pod, session string) bool
+
+	// replicaWait는 시작할 때 다른 게이트웨이의 Lease가 만료되기를 기다리는 최대 시간입니다.
+	// 교체되어 종료된 게이트웨이의 Lease는 이 시간 안에 만료됩니다.
+	replicaWait time.Duration
+
+	mu sync.RWMutex
+	// alive는 마지막으로 확인했을 때 Lease가 만료되지 않은 게이트웨이입니다.
+	alive map[string]bool
+}
+
+func newClaimer(clientset kubernetes.Interface, namespace string, held func(pod *corev1.Pod, session string) bool) (*claimer, error) {
+	id, err := gatewayID()
+	if err != nil {
+		return nil, err
+	}
+	return &claimer{
+		clientset:   clientset,
+		namespace:   namespace,
+		id:          id,
+		held:        held,
+		replicaWait: leaseDuration + renewInterval,
+		alive:       map[string]bool{id: true},
+	}, nil
+}
+
+// gatewayID는 이 게이트웨이 프로세스의 ID입니다. 같은 Pod에서 다시 시작해도 이전 프로세스의 점유와 구분되도록
+// 호스트 이름 뒤에 임의의 접미사를 붙입니다. Lease 이름으로 쓰므로 DNS 이름 규칙을 따릅니다.
+func gatewayID() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %v", err)
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(hostname), "-"), "-.")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return fmt.Sprintf("tss-gateway-%s-%s", name, hex.EncodeToString(suffix)), nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// start는 이 게이트웨이의 Lease를 만들고, 다른 게이트웨이의 Lease가 replicaWait 안에 만료되지 않으면 실패합니다.
+// 그 뒤 ctx가 끝날 때까지 Lease를 갱신하며, pods가 반환하는 Pod 중 죽은 게이트웨이의 점유를 풀어 줍니다.
+func (c *claimer) start(ctx context.Context, pods func() []*corev1.Pod) error {
+	if err := c.renew(ctx); err != nil {
+		return fmt.Errorf("failed to create gateway lease: %v", err)
+	}
+	if err := c.waitForSingleReplica(ctx); err != nil {
+		return err
+	}
+	log.Printf("Claiming party pods as gateway %s", c.id)
+
+	go func() {
+		ticker := time.NewTicker(renewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.renew(ctx); err != nil {
+					log.Printf("Failed to renew gateway lease %s: %v", c.id, err)
+				}
+				c.refreshAlive(ctx)
+				if others := c.others(); len(others) > 0 {
+					log.Printf("Other gateways are running (%s); only one gateway replica is supported", strings.Join(others, ", "))
+				}
+				c.reclaim(ctx, pods())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// waitForSingleReplica는 다른 게이트웨이의 Lease가 모두 만료될 때까지 최대 replicaWait 동안 기다립니다.
+// 교체 중인 이전 게이트웨이는 종료되면 Lease를 갱신하지 않으므로 곧 만료되고, 계속 갱신되는 Lease는 다른 복제본입니다.
+func (c *claimer) waitForSingleReplica(ctx context.Context) error {
+	deadline := time.Now().Add(c.replicaWait)
+	for {
+		c.refreshAlive(ctx)
+		others := c.others()
+		if len(others) == 0 {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("other gateways are running (%s); run a single gateway replica (Deployment strategy Recreate)", strings.Join(others, ", "))
+		}
+		log.Printf("Waiting for the leases of gateways %s to expire", strings.Join(others, ", "))
+		select {
+		case <-time.After(min(renewInterval, time.Until(deadline))):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// others는 마지막으로 확인했을 때 살아 있던 다른 게이트웨이를 정렬해 반환합니다.
+func (c *claimer) others() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var others []string
+	for id := range c.alive {
+		if id != c.id {
+			others = append(others, id)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+// renew는 이 게이트웨이의 Lease를 만들거나 갱신합니다.
+func (c *claimer) renew(ctx context.Context) error {
+	leases := c.clientset.CoordinationV1().Leases(c.namespace)
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(leaseDuration / time.Second)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lease, err := leases.Get(ctx, c.id, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = leases.Create(ctx, &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   c.id,
+					Labels: map[string]string{gatewayLabel: gatewayLabelValue},
+				},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       &c.id,
+					LeaseDurationSeconds: &duration,
+					AcquireTime:          &now,
+					RenewTime:            &now,
+				},
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		lease.Spec.RenewTime = &now
+		lease.Spec.LeaseDurationSeconds = &duration
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// refreshAlive는 Lease가 만료되지 않은 게이트웨이 목록을 다시 읽고, 오래전에 만료된 Lease를 지웁니다.
+func (c *claimer) refreshAlive(ctx context.Context) {
+	leases := c.clientset.CoordinationV1().Leases(c.namespace)
+	list, err := leases.List(ctx, metav1.ListOptions{LabelSelector: gatewayLabelSelector})
+	if err != nil {
+		log.Printf("Failed to list gateway leases: %v", err)
+		return
+	}
+
+	alive := map[string]bool{c.id: true}
+	for i := range list.Items {
+		lease := &list.Items[i]
+		expiry := leaseExpiry(lease)
+		if time.Now().Before(expiry) {
+			alive[lease.Name] = true
+			continue
+		}
+		// 점유를 풀 시간을 충분히 준 뒤 지웁니다. 지운 뒤에도 Lease가 없으면 죽은 게이트웨이로 봅니다.
+		if time.Since(expiry) > 10*leaseDuration {
+			if err := leases.Delete(ctx, lease.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				log.Printf("Failed to delete expired gateway lease %s: %v", lease.Name, err)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.alive = alive
+	c.mu.Unlock()
+}
+
+// holderAlive는 게이트웨이 holder가 살아 있는지 확인합니다. 마지막으로 확인한 뒤에 시작한 게이트웨이일 수 있으므로
+// 살아 있다고 알려지지 않은 게이트웨이는 Lease를 직접 읽어 확인합니다.
+func (c *claimer) holderAlive(ctx context.Context, holder string) (bool, error) {
+	c.mu.RLock()
+	alive := c.alive[holder]
+	c.mu.RUnlock()
+	if alive {
+		return true, nil
+	}
+
+	lease, err := c.clientset.CoordinationV1().Leases(c.namespace).Get(ctx, holder, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !time.Now().Before(leaseExpiry(lease)) {
+		return false, nil
+	}
+	c.mu.Lock()
+	c.alive[holder] = true
+	c.mu.Unlock()
+	return true, nil
+}
+
+func leaseExpiry(lease *coordinationv1.Lease) time.Time {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return time.Time{}
+	}
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+}
+
+// claimedElsewhere는 Pod이 살아 있는 다른 게이트웨이에 점유되어 있는지 마지막으로 확인한 게이트웨이 목록으로 판단합니다.
+// 인포머가 Pod을 풀에 넣을지 정할 때 사용하며, 실제로 점유할 수 있는지는 claimPod가 다시 확인합니다.
+func (c *claimer) claimedElsewhere(pod *corev1.Pod) bool {
+	holder := pod.Annotations[claimedByAnnotation]
+	if holder == "" || holder == c.id {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.alive[holder]
+}
+
+// claim은 Pod들을 session으로 점유합니다. 하나라도 점유하지 못하면 이미 점유한 Pod을 풀고 실패합니다.
+func (c *claimer) claim(ctx context.Context, session string, names []string) error {
+	for i, name := range names {
+		if err := c.claimPod(ctx, session, name); err != nil {
+			for _, claimed := range names[:i] {
+				c.unclaimPod(ctx, session, claimed)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// claimPod는 Pod 하나에 점유 어노테이션을 씁니다. Get으로 읽은 resourceVersion으로 Update하므로
+// 그 사이에 Pod이 바뀌면 충돌로 실패합니다. 충돌하면 Pod을 다시 읽어, 다른 게이트웨이가 먼저 점유했는지 확인합니다.
+func (c *claimer) claimPod(ctx context.Context, session, name string) error {
+	pods := c.clientset.CoreV1().Pods(c.namespace)
+	var claimed *claimedError
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod %s: %w", name, err)
+		}
+		if holder := pod.Annotations[claimedByAnnotation]; holder != "" && holder != c.id {
+			alive, err := c.holderAlive(ctx, holder)
+			if err != nil {
+				return fmt.Errorf("failed to check gateway %s: %w", holder, err)
+			}
+			if alive {
+				claimed = &claimedError{pod: name, holder: holder}
+				return claimed
+			}
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[claimedByAnnotation] = c.id
+		pod.Annotations[sessionAnnotation] = session
+		_, err = pods.Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+	if claimed != nil {
+		return claimed
+	}
+	if apierrors.IsConflict(err) {
+		return &claimedError{pod: name, holder: "unknown"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mark pod %s as in use: %w", name, err)
+	}
+	return nil
+}
+
+// unclaim은 session이 점유한 Pod들의 점유를 풉니다. 실패해도 게이트웨이 Lease가 만료되면 다른 게이트웨이가 풀어 줍니다.
+func (c *claimer) unclaim(ctx context.Context, session string, names []string) {
+	for _, name := range names {
+		c.unclaimPod(ctx, session, name)
+	}
+}
+
+func (c *claimer) unclaimPod(ctx context.Context, session, name string) {
+	pods := c.clientset.CoreV1().Pods(c.namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Annotations[claimedByAnnotation] != c.id || pod.Annotations[sessionAnnotation] != session {
+			return nil
+		}
+		delete(pod.Annotations, claimedByAnnotation)
+		delete(pod.Annotations, sessionAnnotation)
+		_, err = pods.Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Printf("Failed to release claim on pod %s: %v", name, err)
+	}
+}
+
+// reclaim은 죽은 게이트웨이가 점유한 채 남긴 Pod과, 풀의 임대가 만료되어 이 게이트웨이의 어노테이션만 남은 Pod의 점유를 풉니다.
+// 인포머 캐시의 resourceVersion으로 Update하므로 그 사이에 Pod이 바뀌었다면 충돌로 실패하며, 다음 확인 때 다시 봅니다.
+func (c *claimer) reclaim(ctx context.Context, pods []*corev1.Pod) {
+	for _, cached := range pods {
+		holder := cached.Annotations[claimedByAnnotation]
+		if holder == "" {
+			continue
+		}
+		if holder == c.id {
+			if c.held(cached, cached.Annotations[sessionAnnotation]) {
+				continue
+			}
+		} else if alive, err := c.holderAlive(ctx, holder); err != nil || alive {
+			continue
+		}
+		pod := cached.DeepCopy()
+		delete(pod.Annotations, claimedByAnnotation)
+		delete(pod.Annotations, sessionAnnotation)
+		_, err := c.clientset.CoreV1().Pods(c.namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		if err != nil {
+			if !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				log.Printf("Failed to reclaim pod %s from gateway %s: %v", pod.Name, holder, err)
+			}
+			continue
+		}
+		log.Printf("Reclaimed pod %s from gateway %s", pod.Name, holder)
+	}
+}
@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTestClaimer는 fake 클라이언트로 claimer를 만듭니다. held가 nil이면 이 게이트웨이의 세션은 Pod을 빌리고 있지 않습니다.
+func newTestClaimer(t *testing.T, clientset *fake.Clientset, held func(pod *corev1.Pod, session string) bool) *claimer {
+	t.Helper()
+	if held == nil {
+		held = func(*corev1.Pod, string) bool { return false }
+	}
+	c, err := newClaimer(clientset, testNamespace, held)
+	if err != nil {
+		t.Fatalf("newClaimer: %v", err)
+	}
+	return c
+}
+
+// createLease는 renewed에 마지막으로 갱신된 게이트웨이 Lease를 만듭니다.
+func createLease(t *testing.T, clientset *fake.Clientset, name string, renewed time.Time) {
+	t.Helper()
+	renewTime := metav1.NewMicroTime(renewed)
+	duration := int32(leaseDuration / time.Second)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{gatewayLabel: gatewayLabelValue}},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &name, LeaseDurationSeconds: &duration, RenewTime: &renewTime},
+	}
+	if _, err := clientset.CoordinationV1().Leases(testNamespace).Create(context.Background(), lease, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create lease %s: %v", name, err)
+	}
+}
+
+// createClaimedPod는 gateway가 session으로 점유한 Pod을 만듭니다. gateway가 비어 있으면 점유하지 않은 Pod입니다.
+func createClaimedPod(t *testing.T, clientset *fake.Clientset, name, gateway, session string) {
+	t.Helper()
+	pod := testPod(name, name, "")
+	if gateway != "" {
+		pod.Annotations = map[string]string{claimedByAnnotation: gateway, sessionAnnotation: session}
+	}
+	if _, err := clientset.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create pod %s: %v", name, err)
+	}
+}
+
+func claimOf(t *testing.T, clientset *fake.Clientset, name string) (gateway, session string) {
+	t.Helper()
+	pod, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get pod %s: %v", name, err)
+	}
+	return pod.Annotations[claimedByAnnotation], pod.Annotations[sessionAnnotation]
+}
+
+func TestClaimAndUnclaim(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createClaimedPod(t, clientset, "party-a", "", "")
+	createClaimedPod(t, clientset, "party-b", "", "")
+	c := newTestClaimer(t, clientset, nil)
+	ctx := context.Background()
+
+	if err := c.claim(ctx, "session-1", []string{"party-a", "party-b"}); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	for _, name := range []string{"party-a", "party-b"} {
+		if gateway, session := claimOf(t, clientset, name); gateway != c.id || session != "session-1" {
+			t.Fatalf("pod %s claimed by %s/%s, want %s/session-1", name, gateway, session, c.id)
+		}
+	}
+
+	// 다른 세션의 unclaim은 점유를 풀지 않습니다.
+	c.unclaim(ctx, "session-2", []string{"party-a"})
+	if gateway, _ := claimOf(t, clientset, "party-a"); gateway != c.id {
+		t.Fatal("unclaim of another session released the pod")
+	}
+	c.unclaim(ctx, "session-1", []string{"party-a", "party-b"})
+	for _, name := range []string{"party-a", "party-b"} {
+		if gateway, session := claimOf(t, clientset, name); gateway != "" || session != "" {
+			t.Fatalf("pod %s is still claimed by %s/%s", name, gateway, session)
+		}
+	}
+}
+
+// 살아 있는 다른 게이트웨이가 점유한 Pod이 있으면 실패하고, 먼저 점유한 Pod의 점유를 풉니다.
+func TestClaimFailsOnPodOfLiveGateway(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createLease(t, clientset, "tss-gateway-other", time.Now())
+	createClaimedPod(t, clientset, "party-a", "", "")
+	createClaimedPod(t, clientset, "party-b", "tss-gateway-other", "session-other")
+	c := newTestClaimer(t, clientset, nil)
+
+	err := c.claim(context.Background(), "session-1", []string{"party-a", "party-b"})
+	var claimed *claimedError
+	if !errors.As(err, &claimed) || claimed.holder != "tss-gateway-other" {
+		t.Fatalf("claim returned %v, want a claimedError held by tss-gateway-other", err)
+	}
+	if gateway, _ := claimOf(t, clientset, "party-a"); gateway != "" {
+		t.Fatalf("party-a is still claimed by %s after the claim failed", gateway)
+	}
+	if gateway, session := claimOf(t, clientset, "party-b"); gateway != "tss-gateway-other" || session != "session-other" {
+		t.Fatalf("party-b claim changed to %s/%s", gateway, session)
+	}
+}
+
+// Lease가 만료되었거나 없는 게이트웨이의 점유는 가져옵니다.
+func TestClaimTakesOverDeadGateway(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createLease(t, clientset, "tss-gateway-expired", time.Now().Add(-2*leaseDuration))
+	createClaimedPod(t, clientset, "party-a", "tss-gateway-expired", "session-old")
+	createClaimedPod(t, clientset, "party-b", "tss-gateway-gone", "session-old")
+	c := newTestClaimer(t, clientset, nil)
+
+	if err := c.claim(context.Background(), "session-1", []string{"party-a", "party-b"}); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	for _, name := range []string{"party-a", "party-b"} {
+		if gateway, session := claimOf(t, clientset, name); gateway != c.id || session != "session-1" {
+			t.Fatalf("pod %s claimed by %s/%s, want %s/session-1", name, gateway, session, c.id)
+		}
+	}
+}
+
+func TestReclaim(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createLease(t, clientset, "tss-gateway-other", time.Now())
+	c := newTestClaimer(t, clientset, func(_ *corev1.Pod, session string) bool { return session == "session-held" })
+	createClaimedPod(t, clientset, "party-dead", "tss-gateway-gone", "session-old")
+	createClaimedPod(t, clientset, "party-expired", c.id, "session-expired")
+	createClaimedPod(t, clientset, "party-held", c.id, "session-held")
+	createClaimedPod(t, clientset, "party-other", "tss-gateway-other", "session-other")
+
+	list, err := clientset.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("List pods: %v", err)
+	}
+	pods := make([]*corev1.Pod, len(list.Items))
+	for i := range list.Items {
+		pods[i] = &list.Items[i]
+	}
+	c.reclaim(context.Background(), pods)
+
+	tests := []struct {
+		pod     string
+		gateway string
+	}{
+		{"party-dead", ""},
+		{"party-expired", ""},
+		{"party-held", c.id},
+		{"party-other", "tss-gateway-other"},
+	}
+	for _, tt := range tests {
+		if gateway, _ := claimOf(t, clientset, tt.pod); gateway != tt.gateway {
+			t.Errorf("pod %s claimed by %q after reclaim, want %q", tt.pod, gateway, tt.gateway)
+		}
+	}
+}
+
+// 다른 게이트웨이의 Lease가 계속 살아 있으면 시작하지 않습니다.
+func TestStartRefusesSecondReplica(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createLease(t, clientset, "tss-gateway-other", time.Now())
+	c := newTestClaimer(t, clientset, nil)
+	c.replicaWait = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.start(ctx, func() []*corev1.Pod { return nil }); err == nil {
+		t.Fatal("start succeeded while another gateway is running")
+	}
+}
+
+// 교체된 게이트웨이의 만료된 Lease는 시작을 막지 않습니다.
+func TestStartIgnoresExpiredLease(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	createLease(t, clientset, "tss-gateway-old", time.Now().Add(-2*leaseDuration))
+	c := newTestClaimer(t, clientset, nil)
+	c.replicaWait = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.start(ctx, func() []*corev1.Pod { return nil }); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := clientset.CoordinationV1().Leases(testNamespace).Get(ctx, c.id, metav1.GetOptions{}); err != nil {
+		t.Fatalf("gateway lease was not created: %v", err)
+	}
+}
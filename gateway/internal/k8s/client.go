@@ -2,27 +2,104 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"gateway/internal/config"
+	"gateway/internal/orchestrator"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
-var podPool *PodPool
+// partyPort는 파티 Pod의 gRPC 포트입니다. 모든 Pod이 같은 포트를 사용합니다.
+const partyPort = 50051
 
-func init() {
-	podPool = NewPodPool()
+// 파티 Pod의 레이블. 게이트웨이는 이 레이블을 가진 Pod만 풀에서 사용합니다.
+const (
+	partyLabel         = "app"
+	partyLabelValue    = "tss-party"
+	partyLabelSelector = partyLabel + "=" + partyLabelValue
+)
+
+// 파티 Pod에 전달하는 환경 변수. 파티(party/internal/config, party/internal/store)가 읽는 이름과 같아야 합니다.
+const (
+	partyKEKEnv            = "PARTY_SHARE_KEK"
+	partyShareStoreEnv     = "PARTY_SHARE_STORE"
+	partyShareNamespaceEnv = "PARTY_SHARE_NAMESPACE"
+	partyGatewayHostEnv    = "PARTY_GATEWAY_HOST"
+	partyGatewayPortEnv    = "PARTY_GATEWAY_PORT"
+
+	// shareKEKSecretKey는 KEK Secret에서 KEK를 담은 항목입니다.
+	shareKEKSecretKey = "kek"
+	// partyShareStore는 파티 Pod의 키 조각 저장소입니다. Pod이 지워지고 다시 만들어져도 조각이 남도록 Secret에 저장합니다.
+	partyShareStore = "secret"
+)
+
+// preParamsDir은 파티 이미지의 사전 파라미터 디렉터리(WORKDIR /root/, preParams.dir: preparams)입니다.
+// Pod의 emptyDir에 두어 컨테이너가 다시 시작되어도 만들어 둔 사전 파라미터를 다시 만들지 않습니다.
+const preParamsDir = "/root/preparams"
+
+// resyncPeriod마다 인포머가 캐시의 모든 Pod을 다시 전달해, 놓친 변경이 있어도 풀이 맞춰집니다.
+const resyncPeriod = 5 * time.Minute
+
+// Orchestrator는 파티를 Kubernetes Pod으로 실행합니다.
+// 파티 레이블의 Pod을 감시하는 인포머로 준비된 Pod을 찾고, 그중 헬스 체크에서 SERVING을 보고한 Pod만 풀에 둡니다.
+//
+// 게이트웨이는 복제본 하나로 실행하지만 교체 중에는 두 프로세스가 겹칠 수 있으므로, 풀에서 빌린 Pod은 세션에 넘기기 전에
+// Pod 어노테이션으로 점유합니다(claimer). 다른 게이트웨이가 점유한 Pod은 점유가 풀릴 때까지 풀에서 뺍니다.
+type Orchestrator struct {
+	*orchestrator.Pool
+
+	clientset kubernetes.Interface
+	monitor   *orchestrator.Monitor
+	claimer   *claimer
+	informer  cache.SharedIndexInformer
+
+	mu sync.Mutex
+	// pods는 파티 ID별로 그 파티를 실행하는 준비된 Pod의 이름입니다.
+	pods map[string]string
+}
+
+func New() (*Orchestrator, error) {
+	clientset, err := getClientset()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %v", err)
+	}
+	return NewWithClientset(clientset)
+}
+
+// NewWithClientset은 주어진 클라이언트로 오케스트레이터를 만듭니다.
+func NewWithClientset(clientset kubernetes.Interface) (*Orchestrator, error) {
+	cfg := config.Get()
+	pool := orchestrator.NewPool(time.Duration(cfg.Pool.LeaseTimeoutSeconds)*time.Second, cfg.Pool.MaxQueueLength)
+	claimer, err := newClaimer(clientset, cfg.Kubernetes.Namespace, func(pod *corev1.Pod, session string) bool {
+		return slices.Contains(pool.Held(session), partyOf(pod))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Orchestrator{
+		Pool:      pool,
+		clientset: clientset,
+		monitor:   orchestrator.NewMonitor(pool, time.Duration(cfg.Orchestrator.HealthCheckSeconds)*time.Second),
+		claimer:   claimer,
+		pods:      make(map[string]string),
+	}, nil
 }
 
 func getClientset() (*kubernetes.Clientset, error) {
@@ -42,123 +119,386 @@ func getClientset() (*kubernetes.Clientset, error) {
 	}
 }
 
-func ListExistingPods() ([]*corev1.Pod, error) {
-	clientset, err := getClientset()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %v", err)
-	}
-
+// Start는 게이트웨이 Lease(다른 게이트웨이 복제본이 실행 중이면 실패), 파티 Pod을 감시하는 인포머와 헬스 체크를 시작하고,
+// 캐시가 채워지면 InitialPodCount보다 적은 만큼 Pod을 더 만듭니다.
+// 인포머는 ctx가 끝날 때까지 Pod의 추가, IP 변경, 준비 상태 변경, 점유 변경, 삭제를 반영합니다.
+func (o *Orchestrator) Start(ctx context.Context) error {
 	cfg := config.Get()
 
-	// 디버깅: 네임스페이스와 라벨 출력
-	log.Printf("Searching for pods in namespace: %s with label: app=tss-party", cfg.Kubernetes.Namespace)
-
-	pods, err := clientset.CoreV1().Pods(cfg.Kubernetes.Namespace).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: "app=keygen",
+	informer := coreinformers.NewFilteredPodInformer(o.clientset, cfg.Kubernetes.Namespace, resyncPeriod, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = partyLabelSelector
+		})
+	// 인포머가 Pod의 어노테이션으로 풀의 키 배정을 맞추기 전에 이 게이트웨이가 아는 배정을 Pod에 기록합니다.
+	if err := o.backfillKeys(ctx); err != nil {
+		return err
+	}
+	o.informer = informer
+	// 다른 게이트웨이가 점유한 Pod을 가려내려면 살아 있는 게이트웨이를 먼저 알아야 합니다.
+	if err := o.claimer.start(ctx, o.cachedPods); err != nil {
+		return err
+	}
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: o.sync,
+		UpdateFunc: func(_, obj interface{}) {
+			o.sync(obj)
+		},
+		DeleteFunc: o.forget,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list existing pods: %v", err)
+		return fmt.Errorf("failed to watch pods: %v", err)
+	}
+	go informer.Run(ctx.Done())
+	go o.monitor.Run(ctx)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("failed to sync pods in namespace %s", cfg.Kubernetes.Namespace)
 	}
 
-	// 디버깅: 찾은 Pod 수 출력
-	log.Printf("Found %d pods", len(pods.Items))
-
-	var existingPods []*corev1.Pod
-	for _, pod := range pods.Items {
-		// 디버깅: 각 Pod의 이름과 상태 출력
-		log.Printf("Pod: %s, Status: %s", pod.Name, pod.Status.Phase)
-		if pod.Status.Phase == corev1.PodRunning {
-			existingPods = append(existingPods, &pod)
+	// 아직 준비되지 않은 Pod도 곧 풀에 들어오므로 종료 중이 아닌 Pod을 모두 셉니다.
+	existing := 0
+	for _, obj := range informer.GetStore().List() {
+		if pod, ok := obj.(*corev1.Pod); ok && pod.DeletionTimestamp == nil {
+			existing++
 		}
 	}
+	log.Printf("Found %d party pods with %s in namespace %s", existing, partyLabelSelector, cfg.Kubernetes.Namespace)
 
-	return existingPods, nil
-}
+	o.restore(ctx)
 
-func CreatePods(m int) error {
-	clientset, err := getClientset()
-	if err != nil {
-		return fmt.Errorf("failed to create Kubernetes client: %v", err)
+	if existing < cfg.Kubernetes.InitialPodCount {
+		// 일부만 실패하면 만들어진 Pod으로 시작합니다.
+		created, err := o.Create(ctx, cfg.Kubernetes.InitialPodCount-existing)
+		if err != nil && len(created) == 0 {
+			return fmt.Errorf("failed to create initial pods: %v", err)
+		}
+		if err != nil {
+			log.Printf("Failed to create some initial pods: %v", err)
+		}
 	}
+	return nil
+}
 
-	cfg := config.Get()
+// sync는 Pod의 현재 상태를 반영합니다. 준비된 Pod은 헬스 체크를 시작하고(IP가 바뀌었으면 새 주소로),
+// 준비되지 않았거나 다른 게이트웨이가 점유한 Pod은 헬스 체크를 멈추고 풀에서 뺍니다.
+// 풀의 워커는 파티 ID로 구분하며, 같은 파티를 다른 Pod이 실행하고 있으면 준비되지 않은 Pod은 무시합니다.
+// 풀의 키 배정은 Pod에 기록된 배정(keysAnnotation)으로 맞춥니다.
+func (o *Orchestrator) sync(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	party := partyOf(pod)
+	if !podReady(pod) {
+		if !o.untrack(party, pod.Name) {
+			return
+		}
+		o.SetKeys(party, keysOf(pod))
+		if o.Has(party) {
+			log.Printf("Pod %s is not ready (phase %s); removing party %s from the pool", pod.Name, pod.Status.Phase, party)
+		}
+		o.monitor.Forget(party)
+		return
+	}
+	o.track(party, pod.Name)
+	o.SetKeys(party, keysOf(pod))
+	if o.claimer.claimedElsewhere(pod) {
+		o.monitor.Forget(party)
+		return
+	}
+	o.monitor.Watch(workerOf(pod))
+}
 
-	for i := 0; i < m; i++ {
-		pod := &corev1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: fmt.Sprintf("%s-%d", cfg.Kubernetes.PodPrefix, i),
-				Labels: map[string]string{
-					"app": "tss-party",
-				},
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{
-					{
-						Name:            "tss-party-container",
-						Image:           cfg.Kubernetes.PodPrefix,
-						ImagePullPolicy: corev1.PullNever,
-
-						Ports: []corev1.ContainerPort{
-							{
-								ContainerPort: 50051,
-							},
-						},
-					},
-				},
-				RestartPolicy: corev1.RestartPolicyOnFailure,
-			},
+// cachedPods는 인포머 캐시의 파티 Pod을 반환합니다.
+func (o *Orchestrator) cachedPods() []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, obj := range o.informer.GetStore().List() {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
 		}
+	}
+	return pods
+}
 
-		createdPod, err := clientset.CoreV1().Pods(cfg.Kubernetes.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+// Wait는 풀에서 워커를 빌린 뒤 Pod들을 세션으로 점유합니다. 그 사이에 다른 게이트웨이가 점유한 Pod이 있으면
+// 그 Pod을 풀에서 빼고 돌려준 뒤, ctx가 끝날 때까지 다시 기다립니다. 점유가 풀린 Pod은 인포머가 다시 풀에 넣습니다.
+func (o *Orchestrator) Wait(ctx context.Context, req orchestrator.Request) ([]orchestrator.Worker, error) {
+	for {
+		workers, err := o.Pool.Wait(ctx, req)
 		if err != nil {
-			return fmt.Errorf("failed to create pod %d: %v", i, err)
+			return nil, err
+		}
+		pods := o.podNames(workerNames(workers))
+		err = o.claimer.claim(ctx, req.Owner, pods)
+		if err == nil {
+			return workers, nil
 		}
 
-		// Pod이 Running 상태가 될 때까지 대기
-		err = waitForPodRunning(clientset, createdPod.Name, cfg.Kubernetes.Namespace)
-		if err != nil {
-			return fmt.Errorf("error waiting for pod to be running: %v", err)
+		var claimed *claimedError
+		if errors.As(err, &claimed) {
+			party := workers[slices.Index(pods, claimed.pod)].Name
+			log.Printf("Party %s was claimed by another gateway; waiting for other parties", party)
+			// 돌려준 Pod을 다음 요청이 바로 빌려 가지 않도록 먼저 풀에서 뺍니다.
+			o.monitor.Forget(party)
+		}
+		o.Pool.Release(req.Owner)
+		if ctx.Err() != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: no %d free parties became available in time", orchestrator.ErrCapacityExhausted, req.N)
+			}
+			return nil, ctx.Err()
+		}
+		if claimed == nil {
+			return nil, err
 		}
+	}
+}
 
-		log.Printf("Created pod: %s in namespace %s with IP: %s", createdPod.Name, createdPod.Namespace, createdPod.Status.PodIP)
-		podPool.AddPod(createdPod)
+// LeaseIdle은 쉬고 있는 워커를 빌린 뒤 Pod들을 점유합니다. 점유하지 못하면 모두 돌려주고 아무것도 빌려주지 않습니다.
+func (o *Orchestrator) LeaseIdle(owner string, n int) []orchestrator.Worker {
+	workers := o.Pool.LeaseIdle(owner, n)
+	if len(workers) == 0 {
+		return nil
+	}
+	pods := o.podNames(workerNames(workers))
+	if err := o.claimer.claim(context.Background(), owner, pods); err != nil {
+		log.Printf("Failed to claim idle parties: %v", err)
+		var claimed *claimedError
+		if errors.As(err, &claimed) {
+			o.monitor.Forget(workers[slices.Index(pods, claimed.pod)].Name)
+		}
+		o.Pool.Release(owner)
+		return nil
 	}
+	return workers
+}
 
-	return nil
+// Release는 owner가 점유한 Pod의 점유를 풀고 워커를 풀에 돌려줍니다.
+func (o *Orchestrator) Release(owner string) {
+	o.claimer.unclaim(context.Background(), owner, o.podNames(o.Held(owner)))
+	o.Pool.Release(owner)
 }
 
-func GetPodFromPool() *corev1.Pod {
-	return podPool.GetPod()
+// forget은 삭제된 Pod의 헬스 체크를 멈추고 풀에서 뺍니다. 키 조각을 보관한 파티의 Pod이면 같은 파티 ID로 다시 만듭니다.
+func (o *Orchestrator) forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	party := partyOf(pod)
+	if o.untrack(party, pod.Name) {
+		if o.Has(party) {
+			log.Printf("Pod %s was deleted; removing party %s from the pool", pod.Name, party)
+		}
+		o.monitor.Forget(party)
+	}
+	go o.replace(context.Background(), pod)
 }
 
-func GetPodsFromPool(m int) ([]*corev1.Pod, error) {
-	var selectedPods []*corev1.Pod
+// Create는 새 파티의 Pod m개를 동시에 만들고 준비될 때까지 기다립니다.
+// 파티 ID는 PodPrefix 뒤에 임의의 접미사를 붙여 만들고, Pod 이름은 파티 ID 뒤에 Kubernetes가 임의의 접미사를 붙여 만듭니다.
+// 일부 Pod만 실패하면 준비된 Pod의 워커와 함께 Pod마다의 에러를 모은 에러를 반환합니다.
+// 준비되지 못한 Pod은 나중에 풀에 들어오지 않도록 지웁니다.
+func (o *Orchestrator) Create(ctx context.Context, m int) ([]orchestrator.Worker, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var workers []orchestrator.Worker
+	var errs []error
+
 	for i := 0; i < m; i++ {
-		pod := podPool.GetPod()
-		if pod == nil {
-			return nil, fmt.Errorf("not enough pods in the pool")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker, err := o.createPod(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Failed to create party pod: %v", err)
+				errs = append(errs, err)
+				return
+			}
+			workers = append(workers, worker)
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return workers, fmt.Errorf("%d of %d pods failed: %w", len(errs), m, errors.Join(errs...))
+	}
+	return workers, nil
+}
+
+// createPod는 새 파티의 Pod 하나를 만들고 준비될 때까지 기다립니다. 파티가 SERVING을 보고하면 풀에 들어갑니다.
+func (o *Orchestrator) createPod(ctx context.Context) (orchestrator.Worker, error) {
+	cfg := config.Get()
+	pods := o.clientset.CoreV1().Pods(cfg.Kubernetes.Namespace)
+
+	party, err := newPartyID()
+	if err != nil {
+		return orchestrator.Worker{}, err
+	}
+	pod := newPod(party, "")
+
+	createdPod, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return orchestrator.Worker{}, fmt.Errorf("failed to create pod: %v", err)
+	}
+
+	readyPod, err := o.waitForPodReady(ctx, createdPod.Name, cfg.Kubernetes.Namespace)
+	if err != nil {
+		// 요청이 취소되었어도 Pod은 지워야 하므로 ctx를 쓰지 않습니다.
+		if delErr := pods.Delete(context.Background(), createdPod.Name, metav1.DeleteOptions{}); delErr != nil {
+			log.Printf("Failed to delete pod %s that did not become ready: %v", createdPod.Name, delErr)
 		}
-		selectedPods = append(selectedPods, pod)
+		return orchestrator.Worker{}, fmt.Errorf("pod %s did not become ready: %v", createdPod.Name, err)
 	}
-	return selectedPods, nil
+
+	log.Printf("Created pod: %s for party %s in namespace %s with IP: %s", readyPod.Name, party, readyPod.Namespace, readyPod.Status.PodIP)
+	worker := workerOf(readyPod)
+	o.track(party, readyPod.Name)
+	o.monitor.Watch(worker)
+	return worker, nil
 }
 
-func CheckPodResourceAvailability(pod *corev1.Pod) bool {
-	// Pod의 리소스 상태를 확인하는 로직을 추가합니다.
-	// 예를 들어, Pod의 상태가 Running인지 확인합니다.
-	return pod.Status.Phase == corev1.PodRunning
+// newPod는 party를 실행하는 Pod을 만듭니다. name이 비어 있으면 파티 ID 뒤에 Kubernetes가 임의의 접미사를 붙여 이름을 만듭니다.
+func newPod(party, name string) *corev1.Pod {
+	cfg := config.Get()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				partyLabel:   partyLabelValue,
+				partyIDLabel: party,
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: cfg.Kubernetes.PartyServiceAccount,
+			Containers: []corev1.Container{
+				{
+					Name:            "tss-party-container",
+					Image:           cfg.Kubernetes.PoImage,
+					ImagePullPolicy: corev1.PullNever,
+
+					Ports: []corev1.ContainerPort{
+						{
+							ContainerPort: partyPort,
+						},
+					},
+					Env: []corev1.EnvVar{
+						{
+							Name:  partyNameEnv,
+							Value: party,
+						},
+						{
+							Name: partyKEKEnv,
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: cfg.Kubernetes.ShareKEKSecret},
+									Key:                  shareKEKSecretKey,
+								},
+							},
+						},
+						{
+							Name:  partyShareStoreEnv,
+							Value: partyShareStore,
+						},
+						{
+							Name: partyShareNamespaceEnv,
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+							},
+						},
+						{
+							Name:  partyGatewayHostEnv,
+							Value: cfg.Kubernetes.GatewayHost,
+						},
+						{
+							Name:  partyGatewayPortEnv,
+							Value: strconv.Itoa(cfg.GRPC.Port),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "preparams",
+							MountPath: preParamsDir,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "preparams",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+		},
+	}
+	if name == "" {
+		pod.GenerateName = party + "-"
+	}
+	return pod
 }
 
-func GetPodPool() *PodPool {
-	return podPool
+func (o *Orchestrator) Delete(ctx context.Context, name string) error {
+	pod := o.podName(name)
+	// 풀의 배정이 아직 반영되지 않았어도 키 조각을 보관한 파티는 지우지 않습니다.
+	holds, err := o.holdsKeys(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("failed to check pod %s of party %s: %v", pod, name, err)
+	}
+	if holds {
+		return fmt.Errorf("party %s holds key shares; not deleting pod %s", name, pod)
+	}
+	o.monitor.Forget(name)
+	err = o.clientset.CoreV1().Pods(config.Get().Kubernetes.Namespace).Delete(ctx, pod, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete pod %s of party %s: %v", pod, name, err)
+	}
+	return nil
 }
 
-func waitForPodRunning(clientset *kubernetes.Clientset, podName, namespace string) error {
-	return wait.PollImmediate(time.Second, time.Minute*5, func() (bool, error) {
-		pod, err := clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+// waitForPodReady는 Pod이 준비될 때까지 기다린 뒤 IP가 채워진 Pod을 반환합니다.
+func (o *Orchestrator) waitForPodReady(ctx context.Context, podName, namespace string) (*corev1.Pod, error) {
+	var ready *corev1.Pod
+	err := wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		pod, err := o.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 		if err != nil {
 			return false, err
 		}
-		return pod.Status.Phase == corev1.PodRunning, nil
+		ready = pod
+		return podReady(pod), nil
 	})
+	return ready, err
+}
+
+// podReady는 Pod이 실행 중이고 Ready 조건을 만족하며 종료 중이 아닌지 확인합니다.
+func podReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func workerNames(workers []orchestrator.Worker) []string {
+	names := make([]string, len(workers))
+	for i, worker := range workers {
+		names[i] = worker.Name
+	}
+	return names
+}
+
+func workerOf(pod *corev1.Pod) orchestrator.Worker {
+	return orchestrator.Worker{Name: partyOf(pod), IP: pod.Status.PodIP, Port: partyPort}
 }
@@ -0,0 +1,274 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"gateway/internal/config"
+	"gateway/internal/orchestrator"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "tss"
+
+// 테스트 파티의 주소. 모든 파티가 partyPort를 사용하므로 루프백 주소로 파티를 구분합니다.
+const (
+	testIP      = "127.0.0.2"
+	testMovedIP = "127.0.0.3"
+)
+
+// startParty는 ip:partyPort에서 SERVING을 보고하는 헬스 서버를 실행합니다.
+// 포트를 열 수 없는 환경(다른 프로세스가 사용 중이거나 127.0.0.2 이상의 루프백 주소가 없는 경우)에서는 테스트를 건너뜁니다.
+func startParty(t *testing.T, ip string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", ip, partyPort))
+	if err != nil {
+		t.Skipf("cannot listen on %s:%d: %v", ip, partyPort, err)
+	}
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+}
+
+// startOrchestrator는 fake 클라이언트로 오케스트레이터를 시작합니다. Pod을 미리 만들지 않습니다.
+func startOrchestrator(t *testing.T, objects ...*corev1.Pod) (*Orchestrator, *fake.Clientset) {
+	t.Helper()
+	cfg := config.Get()
+	cfg.Kubernetes.Namespace = testNamespace
+	cfg.Kubernetes.PodPrefix = "tss-party"
+	cfg.Kubernetes.InitialPodCount = 0
+	cfg.Orchestrator.HealthCheckSeconds = 1
+	cfg.Pool.LeaseTimeoutSeconds = 60
+
+	clientset := fake.NewSimpleClientset()
+	for _, pod := range objects {
+		if _, err := clientset.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Create pod %s: %v", pod.Name, err)
+		}
+	}
+	o, err := NewWithClientset(clientset)
+	if err != nil {
+		t.Fatalf("NewWithClientset: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := o.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return o, clientset
+}
+
+// testPod는 party를 실행하는 Pod입니다. ip가 비어 있지 않으면 준비된 Pod입니다.
+func testPod(name, party, ip string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{partyLabel: partyLabelValue},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if party != "" {
+		pod.Labels[partyIDLabel] = party
+	}
+	if ip != "" {
+		setReady(pod, ip, true)
+	}
+	return pod
+}
+
+func setReady(pod *corev1.Pod, ip string, ready bool) {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.PodIP = ip
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+}
+
+func updatePod(t *testing.T, clientset *fake.Clientset, name string, update func(pod *corev1.Pod)) {
+	t.Helper()
+	pods := clientset.CoreV1().Pods(testNamespace)
+	pod, err := pods.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get pod %s: %v", name, err)
+	}
+	update(pod)
+	if _, err := pods.Update(context.Background(), pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update pod %s: %v", name, err)
+	}
+}
+
+// eventually는 cond가 참이 될 때까지 기다립니다.
+func eventually(t *testing.T, msg string, cond func() bool) {
+	t.Helper()
+	err := wait.PollUntilContextTimeout(context.Background(), 20*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		return cond(), nil
+	})
+	if err != nil {
+		t.Fatalf("timed out waiting until %s", msg)
+	}
+}
+
+func worker(o *Orchestrator, party string) (orchestrator.Worker, bool) {
+	for _, w := range o.List() {
+		if w.Name == party {
+			return w, true
+		}
+	}
+	return orchestrator.Worker{}, false
+}
+
+func TestInformerAddsReadyPod(t *testing.T) {
+	startParty(t, testIP)
+	o, _ := startOrchestrator(t, testPod("tss-party-a-x1", "tss-party-a", testIP))
+
+	eventually(t, "party tss-party-a is in the pool", func() bool { return o.Has("tss-party-a") })
+	w, _ := worker(o, "tss-party-a")
+	if w.IP != testIP || w.Port != partyPort {
+		t.Fatalf("worker address = %s, want %s:%d", w.Address(), testIP, partyPort)
+	}
+	if got := o.podName("tss-party-a"); got != "tss-party-a-x1" {
+		t.Fatalf("podName = %s, want tss-party-a-x1", got)
+	}
+}
+
+func TestInformerAddsPodWhenItBecomesReady(t *testing.T) {
+	startParty(t, testIP)
+	o, clientset := startOrchestrator(t, testPod("tss-party-a-x1", "tss-party-a", ""))
+
+	// 준비되지 않은 Pod은 헬스 체크를 하지 않으므로 풀에 들어가지 않습니다.
+	time.Sleep(200 * time.Millisecond)
+	if o.Has("tss-party-a") {
+		t.Fatal("pending pod was added to the pool")
+	}
+
+	updatePod(t, clientset, "tss-party-a-x1", func(pod *corev1.Pod) { setReady(pod, testIP, true) })
+	eventually(t, "ready pod is in the pool", func() bool { return o.Has("tss-party-a") })
+}
+
+func TestInformerFollowsIPChange(t *testing.T) {
+	startParty(t, testIP)
+	startParty(t, testMovedIP)
+	o, clientset := startOrchestrator(t, testPod("tss-party-a-x1", "tss-party-a", testIP))
+	eventually(t, "party tss-party-a is in the pool", func() bool { return o.Has("tss-party-a") })
+
+	updatePod(t, clientset, "tss-party-a-x1", func(pod *corev1.Pod) { pod.Status.PodIP = testMovedIP })
+	eventually(t, "worker moved to "+testMovedIP, func() bool {
+		w, ok := worker(o, "tss-party-a")
+		return ok && w.IP == testMovedIP
+	})
+}
+
+func TestInformerRemovesPodThatIsNotReady(t *testing.T) {
+	startParty(t, testIP)
+	o, clientset := startOrchestrator(t, testPod("tss-party-a-x1", "tss-party-a", testIP))
+	eventually(t, "party tss-party-a is in the pool", func() bool { return o.Has("tss-party-a") })
+
+	updatePod(t, clientset, "tss-party-a-x1", func(pod *corev1.Pod) { setReady(pod, testIP, false) })
+	eventually(t, "not ready pod left the pool", func() bool { return !o.Has("tss-party-a") })
+
+	updatePod(t, clientset, "tss-party-a-x1", func(pod *corev1.Pod) { setReady(pod, testIP, true) })
+	eventually(t, "ready pod is back in the pool", func() bool { return o.Has("tss-party-a") })
+}
+
+func TestInformerRemovesDeletedPod(t *testing.T) {
+	startParty(t, testIP)
+	o, clientset := startOrchestrator(t, testPod("tss-party-a-x1", "tss-party-a", testIP))
+	eventually(t, "party tss-party-a is in the pool", func() bool { return o.Has("tss-party-a") })
+
+	if err := clientset.CoreV1().Pods(testNamespace).Delete(context.Background(), "tss-party-a-x1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete pod: %v", err)
+	}
+	eventually(t, "deleted pod left the pool", func() bool { return !o.Has("tss-party-a") })
+
+	// 키 조각을 보관하지 않은 파티의 Pod은 다시 만들지 않습니다.
+	time.Sleep(200 * time.Millisecond)
+	list, err := clientset.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("List pods: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Fatalf("found %d pods after deleting the only pod, want 0", len(list.Items))
+	}
+}
+
+// 파티 ID 레이블이 없는 Pod(이전 버전이 만든 Pod)은 Pod 이름이 파티 ID입니다.
+func TestInformerUsesPodNameWithoutPartyLabel(t *testing.T) {
+	startParty(t, testIP)
+	o, _ := startOrchestrator(t, testPod("tss-party-legacy", "", testIP))
+	eventually(t, "legacy pod is in the pool", func() bool { return o.Has("tss-party-legacy") })
+}
+
+// 키 조각을 보관한 파티의 Pod이 지워지면 같은 파티 ID와 배정으로 다시 만듭니다.
+func TestInformerRecreatesShareHolder(t *testing.T) {
+	startParty(t, testIP)
+	pod := testPod("tss-party-a-x1", "tss-party-a", testIP)
+	pod.UID = "uid-1"
+	pod.Annotations = map[string]string{keysAnnotation: "key-1"}
+	o, clientset := startOrchestrator(t, pod)
+	eventually(t, "party tss-party-a is in the pool", func() bool { return o.Has("tss-party-a") })
+	if keys := o.Keys("tss-party-a"); !slices.Equal(keys, []string{"key-1"}) {
+		t.Fatalf("Keys = %v, want [key-1]", keys)
+	}
+
+	if err := o.Delete(context.Background(), "tss-party-a"); err == nil {
+		t.Fatal("Delete of a share holder succeeded")
+	}
+
+	pods := clientset.CoreV1().Pods(testNamespace)
+	if err := pods.Delete(context.Background(), "tss-party-a-x1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete pod: %v", err)
+	}
+	name := replacementName("tss-party-a", "uid-1")
+	eventually(t, "pod "+name+" is recreated", func() bool {
+		_, err := pods.Get(context.Background(), name, metav1.GetOptions{})
+		return err == nil
+	})
+	recreated, _ := pods.Get(context.Background(), name, metav1.GetOptions{})
+	if partyOf(recreated) != "tss-party-a" || recreated.Annotations[keysAnnotation] != "key-1" {
+		t.Fatalf("recreated pod has party %s and keys %q", partyOf(recreated), recreated.Annotations[keysAnnotation])
+	}
+}
+
+// Assign과 Unassign은 풀의 배정과 함께 Pod의 키 ID 목록을 정렬해 기록합니다.
+func TestAssignRecordsKeysOnPod(t *testing.T) {
+	o, clientset := startOrchestrator(t, testPod("tss-party-a", "", ""))
+	keysOnPod := func() string {
+		pod, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), "tss-party-a", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Get pod: %v", err)
+		}
+		return pod.Annotations[keysAnnotation]
+	}
+
+	o.Assign("key-2", "tss-party-a")
+	o.Assign("key-1", "tss-party-a")
+	if got := keysOnPod(); got != "key-1,key-2" {
+		t.Fatalf("keys annotation = %q, want key-1,key-2", got)
+	}
+	o.Unassign("key-2", "tss-party-a")
+	if got := keysOnPod(); got != "key-1" {
+		t.Fatalf("keys annotation = %q, want key-1", got)
+	}
+	o.Unassign("key-1", "tss-party-a")
+	if got := keysOnPod(); got != "" {
+		t.Fatalf("keys annotation = %q after unassigning every key", got)
+	}
+	if o.Assigned("tss-party-a") {
+		t.Fatal("party is still assigned in the pool")
+	}
+}
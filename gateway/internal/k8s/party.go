@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"gateway/internal/config"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 파티 ID. 파티는 PARTY_NAME으로 받은 파티 ID를 키 생성과 서명에서 자신의 이름으로 쓰고, 키 조각 Secret의 이름에도 씁니다.
+// Pod이 지워져 다시 만들어도 같은 파티 ID를 붙여 조각을 다시 읽게 하므로, 게이트웨이는 Pod 이름이 아닌 파티 ID로 워커를 구분합니다.
+// 파티 ID 레이블이 없는 Pod(이전 버전의 게이트웨이가 만든 Pod)은 Pod 이름이 파티 ID입니다.
+const (
+	partyIDLabel = "tss.bnb-chain/party"
+	partyNameEnv = "PARTY_NAME"
+)
+
+// partyOf는 Pod이 실행하는 파티의 ID를 반환합니다.
+func partyOf(pod *corev1.Pod) string {
+	if party := pod.Labels[partyIDLabel]; party != "" {
+		return party
+	}
+	return pod.Name
+}
+
+// newPartyID는 새 파티의 ID를 만듭니다. Pod 이름은 파티 ID 뒤에 Kubernetes가 임의의 접미사를 붙여 만듭니다.
+func newPartyID() (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", config.Get().Kubernetes.PodPrefix, hex.EncodeToString(suffix)), nil
+}
+
+// replacementName은 파티 ID로 다시 만드는 Pod의 이름입니다. 여러 게이트웨이가 같은 이유(seed)로 Pod을 다시 만들더라도
+// 이름이 같아 Pod은 하나만 만들어집니다.
+func replacementName(party, seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return fmt.Sprintf("%s-%s", party, hex.EncodeToString(sum[:4]))
+}
+
+// track은 party를 지금 pod이 실행한다고 기록합니다.
+func (o *Orchestrator) track(party, pod string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.pods[party] = pod
+}
+
+// untrack은 pod이 party를 실행하던 Pod이면 기록을 지우고 true를 반환합니다.
+// 같은 파티를 다른 Pod이 실행하고 있으면(지워지는 Pod과 다시 만든 Pod) 아무것도 하지 않고 false를 반환합니다.
+func (o *Orchestrator) untrack(party, pod string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	current, ok := o.pods[party]
+	if ok && current != pod {
+		return false
+	}
+	delete(o.pods, party)
+	return true
+}
+
+// podName은 party를 실행하는 Pod의 이름을 반환합니다. 모르는 파티는 파티 ID를 Pod 이름으로 봅니다.
+func (o *Orchestrator) podName(party string) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if pod, ok := o.pods[party]; ok {
+		return pod
+	}
+	return party
+}
+
+func (o *Orchestrator) podNames(parties []string) []string {
+	names := make([]string, len(parties))
+	for i, party := range parties {
+		names[i] = o.podName(party)
+	}
+	return names
+}
+
+// hasPod는 인포머 캐시에 party를 실행하는, 종료 중이 아닌 Pod이 있는지 확인합니다.
+func (o *Orchestrator) hasPod(party string) bool {
+	for _, pod := range o.cachedPods() {
+		if partyOf(pod) == party && pod.DeletionTimestamp == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// replace는 키 조각을 보관한 파티의 Pod이 지워졌을 때 같은 파티 ID로 Pod을 다시 만듭니다.
+// 새 Pod은 파티 ID로 Secret에 저장된 키 조각을 다시 읽습니다. 이름은 지워진 Pod의 UID로 정합니다.
+func (o *Orchestrator) replace(ctx context.Context, deleted *corev1.Pod) {
+	party := partyOf(deleted)
+	if !o.Assigned(party) || o.hasPod(party) {
+		return
+	}
+	log.Printf("Pod %s of party %s holding key shares was deleted; recreating it", deleted.Name, party)
+	o.recreate(ctx, party, replacementName(party, string(deleted.UID)))
+}
+
+// restore는 게이트웨이가 실행되지 않는 동안 Pod이 지워진, 키 조각을 보관한 파티의 Pod을 다시 만듭니다.
+func (o *Orchestrator) restore(ctx context.Context) {
+	for _, party := range o.Holders() {
+		if o.hasPod(party) {
+			continue
+		}
+		log.Printf("Party %s holds key shares but has no pod; recreating it", party)
+		o.recreate(ctx, party, replacementName(party, "restore"))
+	}
+}
+
+// recreate는 party의 Pod을 name으로 만들고 파티의 키 배정을 기록합니다. 준비될 때까지 기다리지 않으며, 준비되면 인포머가 풀에 넣습니다.
+// 다른 게이트웨이가 이미 같은 이름으로 만들었으면 그대로 둡니다.
+func (o *Orchestrator) recreate(ctx context.Context, party, name string) {
+	pod := newPod(party, name)
+	setKeys(pod, o.Keys(party))
+	pods := o.clientset.CoreV1().Pods(config.Get().Kubernetes.Namespace)
+	_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		log.Printf("Failed to recreate pod %s for party %s: %v", name, party, err)
+	}
+}
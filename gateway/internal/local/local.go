@@ -0,0 +1,213 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"gateway/internal/config"
+	"gateway/internal/orchestrator"
+)
+
+// startTimeout은 party 프로세스가 gRPC 포트를 열 때까지 기다리는 시간입니다.
+const startTimeout = 30 * time.Second
+
+// Orchestrator는 party 바이너리를 로컬 프로세스로 실행합니다. 클러스터 없이 개발 PC나 CI에서
+// 전체 키 생성을 실행할 때 사용합니다. 워커마다 WorkDir 아래에 작업 디렉터리를 만들고,
+// 그 안에 config.yaml을 써서 서로 다른 포트, 사전 파라미터 디렉터리, 키 조각 디렉터리를 사용하게 합니다.
+// 워커 프로세스는 게이트웨이의 환경 변수(PARTY_SHARE_KEK 등)를 그대로 물려받습니다.
+type Orchestrator struct {
+	*orchestrator.Pool
+	monitor *orchestrator.Monitor
+
+	mu       sync.Mutex
+	next     int
+	commands map[string]*exec.Cmd
+}
+
+func New() *Orchestrator {
+	cfg := config.Get()
+	pool := orchestrator.NewPool(time.Duration(cfg.Pool.LeaseTimeoutSeconds)*time.Second, cfg.Pool.MaxQueueLength)
+	return &Orchestrator{
+		Pool:     pool,
+		monitor:  orchestrator.NewMonitor(pool, time.Duration(cfg.Orchestrator.HealthCheckSeconds)*time.Second),
+		commands: make(map[string]*exec.Cmd),
+	}
+}
+
+// Start는 설정된 수의 party 프로세스를 실행합니다.
+// 이전 실행의 작업 디렉터리가 남아 있으면 같은 이름으로 다시 사용하므로 키 조각도 그대로 남습니다.
+func (o *Orchestrator) Start(ctx context.Context) error {
+	go o.monitor.Run(ctx)
+	workers := config.Get().Orchestrator.Local.Workers
+	if _, err := o.Create(ctx, workers); err != nil {
+		return fmt.Errorf("failed to start local parties: %v", err)
+	}
+	return nil
+}
+
+func (o *Orchestrator) Create(ctx context.Context, n int) ([]orchestrator.Worker, error) {
+	var workers []orchestrator.Worker
+	for i := 0; i < n; i++ {
+		worker, err := o.spawn(ctx)
+		if err != nil {
+			return workers, err
+		}
+		o.monitor.Watch(worker)
+		workers = append(workers, worker)
+	}
+	return workers, nil
+}
+
+// Delete는 party 프로세스를 종료합니다. 작업 디렉터리(키 조각 포함)는 지우지 않습니다.
+func (o *Orchestrator) Delete(ctx context.Context, name string) error {
+	o.monitor.Forget(name)
+
+	o.mu.Lock()
+	cmd, ok := o.commands[name]
+	delete(o.commands, name)
+	o.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown party process: %s", name)
+	}
+	if err := cmd.Process.Kill(); err != nil {
+		return fmt.Errorf("failed to stop party %s: %v", name, err)
+	}
+	return nil
+}
+
+// spawn은 다음 번호의 party 프로세스를 실행하고 gRPC 포트가 열릴 때까지 기다립니다.
+func (o *Orchestrator) spawn(ctx context.Context) (orchestrator.Worker, error) {
+	cfg := config.Get()
+
+	o.mu.Lock()
+	index := o.next
+	o.next++
+	o.mu.Unlock()
+
+	worker := orchestrator.Worker{
+		Name: fmt.Sprintf("%s-%d", cfg.Orchestrator.Local.NamePrefix, index),
+		IP:   "127.0.0.1",
+		Port: int32(cfg.Orchestrator.Local.BasePort + index),
+	}
+
+	// 이전 게이트웨이가 남긴 프로세스가 포트를 쓰고 있으면 그 프로세스를 새 워커로 착각하게 됩니다.
+	if conn, err := net.DialTimeout("tcp", worker.Address(), time.Second); err == nil {
+		conn.Close()
+		return worker, fmt.Errorf("port %d for party %s is already in use", worker.Port, worker.Name)
+	}
+
+	dir, err := filepath.Abs(filepath.Join(cfg.Orchestrator.Local.WorkDir, worker.Name))
+	if err != nil {
+		return worker, err
+	}
+	if err := writePartyConfig(dir, worker); err != nil {
+		return worker, fmt.Errorf("failed to prepare party %s: %v", worker.Name, err)
+	}
+
+	binary, err := filepath.Abs(cfg.Orchestrator.Local.Binary)
+	if err != nil {
+		return worker, err
+	}
+	logFile, err := os.OpenFile(filepath.Join(dir, "party.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return worker, fmt.Errorf("failed to open log for party %s: %v", worker.Name, err)
+	}
+	defer logFile.Close()
+
+	// 프로세스는 게이트웨이 요청과 무관하게 계속 실행되어야 하므로 ctx에 묶지 않습니다.
+	cmd := exec.Command(binary)
+	cmd.Dir = dir
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		return worker, fmt.Errorf("failed to start party %s: %v", worker.Name, err)
+	}
+
+	o.mu.Lock()
+	o.commands[worker.Name] = cmd
+	o.mu.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		log.Printf("Local party %s exited: %v", worker.Name, err)
+		o.monitor.Forget(worker.Name)
+	}()
+
+	if err := waitForPort(ctx, worker.Address()); err != nil {
+		cmd.Process.Kill()
+		return worker, fmt.Errorf("party %s did not start: %v (see %s)", worker.Name, err, logFile.Name())
+	}
+	log.Printf("Started local party %s on %s (pid %d)", worker.Name, worker.Address(), cmd.Process.Pid)
+	return worker, nil
+}
+
+// partyConfig는 party의 config.yaml 중 로컬 실행에 필요한 항목입니다.
+type partyConfig struct {
+	GRPC struct {
+		Port int32 `yaml:"port"`
+	} `yaml:"grpc"`
+	Gateway struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"gateway"`
+	Party struct {
+		Name string `yaml:"name"`
+	} `yaml:"party"`
+	PreParams struct {
+		Dir      string `yaml:"dir"`
+		PoolSize int    `yaml:"poolSize"`
+	} `yaml:"preParams"`
+	ShareStore struct {
+		Backend string `yaml:"backend"`
+		Dir     string `yaml:"dir"`
+	} `yaml:"shareStore"`
+}
+
+func writePartyConfig(dir string, worker orchestrator.Worker) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	var pc partyConfig
+	pc.GRPC.Port = worker.Port
+	pc.Gateway.Host = "127.0.0.1"
+	pc.Gateway.Port = config.Get().GRPC.Port
+	pc.Party.Name = worker.Name
+	pc.PreParams.Dir = "preparams"
+	pc.PreParams.PoolSize = config.Get().Orchestrator.Local.PreParamsPoolSize
+	pc.ShareStore.Backend = "file"
+	pc.ShareStore.Dir = "shares"
+
+	data, err := yaml.Marshal(&pc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "config.yaml"), data, 0o600)
+}
+
+func waitForPort(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	for {
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	grpcClient "gateway/internal/grpc"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Monitor는 실행 중인 워커에 주기적으로 gRPC 헬스 체크를 보내, SERVING을 보고한 워커만 풀에 둡니다.
+// 파티는 gRPC 서버를 연 뒤에도 사전 파라미터가 준비될 때까지 NOT_SERVING을 보고하므로,
+// 프로세스나 Pod이 실행 중이라는 것만으로는 키 생성에 빌려줄 수 없습니다.
+// SERVING이 아닌 워커는 풀에서 빼고, 다시 SERVING을 보고하면 풀에 넣습니다.
+type Monitor struct {
+	pool     *Pool
+	interval time.Duration
+
+	mu      sync.Mutex
+	workers map[string]Worker
+}
+
+func NewMonitor(pool *Pool, interval time.Duration) *Monitor {
+	return &Monitor{
+		pool:     pool,
+		interval: interval,
+		workers:  make(map[string]Worker),
+	}
+}
+
+// Watch는 워커의 헬스 체크를 시작합니다. 이미 감시 중인 워커면 주소를 바꿉니다.
+// 첫 체크는 바로 백그라운드에서 실행합니다.
+func (m *Monitor) Watch(worker Worker) {
+	m.mu.Lock()
+	previous, ok := m.workers[worker.Name]
+	m.workers[worker.Name] = worker
+	m.mu.Unlock()
+
+	if ok && previous.Address() != worker.Address() {
+		// 이전 주소로 SERVING을 확인한 워커는 새 주소로 확인할 때까지 빌려주지 않습니다.
+		log.Printf("Party %s moved from %s to %s", worker.Name, previous.Address(), worker.Address())
+		m.pool.Remove(worker.Name)
+	}
+	go m.check(worker)
+}
+
+// Forget은 워커의 헬스 체크를 멈추고 풀에서 뺍니다.
+func (m *Monitor) Forget(name string) {
+	m.mu.Lock()
+	delete(m.workers, name)
+	m.mu.Unlock()
+	m.pool.Remove(name)
+}
+
+// CheckAll은 감시 중인 모든 워커를 동시에 확인하고 끝날 때까지 기다립니다.
+func (m *Monitor) CheckAll() {
+	m.mu.Lock()
+	workers := make([]Worker, 0, len(m.workers))
+	for _, worker := range m.workers {
+		workers = append(workers, worker)
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(worker Worker) {
+			defer wg.Done()
+			m.check(worker)
+		}(worker)
+	}
+	wg.Wait()
+}
+
+// Run은 ctx가 끝날 때까지 interval마다 CheckAll을 실행합니다.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.CheckAll()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check는 워커 하나를 확인하고 결과를 풀에 반영합니다.
+func (m *Monitor) check(worker Worker) {
+	status, err := grpcClient.CheckHealth(worker.Address())
+	serving := err == nil && status == healthpb.HealthCheckResponse_SERVING
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// 확인하는 동안 Forget되었거나 주소가 바뀐 워커의 결과는 버립니다.
+	if current, ok := m.workers[worker.Name]; !ok || current != worker {
+		return
+	}
+
+	switch {
+	case serving && !m.pool.Has(worker.Name):
+		log.Printf("Party %s at %s is serving", worker.Name, worker.Address())
+		m.pool.Add(worker)
+	case !serving && m.pool.Has(worker.Name):
+		if err == nil {
+			err = fmt.Errorf("status %s", status)
+		}
+		log.Printf("Party %s at %s is unavailable: %v", worker.Name, worker.Address(), err)
+		m.pool.Remove(worker.Name)
+	}
+}
@@ -0,0 +1,150 @@
+package orchestrator
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakeParty는 루프백 주소에서 헬스 서비스만 제공하는 파티입니다.
+type fakeParty struct {
+	worker Worker
+	health *health.Server
+	server *grpc.Server
+}
+
+func newFakeParty(t *testing.T, name string, status healthpb.HealthCheckResponse_ServingStatus) *fakeParty {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	p := &fakeParty{
+		worker: Worker{Name: name, IP: "127.0.0.1", Port: int32(lis.Addr().(*net.TCPAddr).Port)},
+		health: health.NewServer(),
+		server: grpc.NewServer(),
+	}
+	p.set(status)
+	healthpb.RegisterHealthServer(p.server, p.health)
+	go p.server.Serve(lis)
+	t.Cleanup(p.server.Stop)
+	return p
+}
+
+func (p *fakeParty) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	p.health.SetServingStatus("", status)
+}
+
+const (
+	serving    = healthpb.HealthCheckResponse_SERVING
+	notServing = healthpb.HealthCheckResponse_NOT_SERVING
+)
+
+// 파티는 SERVING을 보고해야 풀에 들어가고, 다시 NOT_SERVING을 보고하면 풀에서 빠집니다.
+func TestMonitorFollowsHealth(t *testing.T) {
+	pool := NewPool(time.Minute, 10)
+	monitor := NewMonitor(pool, time.Hour)
+	party := newFakeParty(t, "party-a", notServing)
+
+	monitor.Watch(party.worker)
+	monitor.CheckAll()
+	if pool.Has("party-a") {
+		t.Fatal("party reporting NOT_SERVING was added to the pool")
+	}
+
+	party.set(serving)
+	monitor.CheckAll()
+	if !pool.Has("party-a") {
+		t.Fatal("party reporting SERVING was not added to the pool")
+	}
+
+	party.set(notServing)
+	monitor.CheckAll()
+	if pool.Has("party-a") {
+		t.Fatal("party that went back to NOT_SERVING was not removed from the pool")
+	}
+
+	party.set(serving)
+	monitor.CheckAll()
+	if !pool.Has("party-a") {
+		t.Fatal("party that recovered was not added back to the pool")
+	}
+}
+
+// 빌려준 워커나 키가 배정된 워커도 NOT_SERVING을 보고하면 풀에서 빠지고, 돌려받아도 다시 빌려주지 않습니다.
+// 다시 SERVING을 보고하면 임대와 키 배정을 그대로 가진 채 풀로 돌아옵니다.
+func TestMonitorRemovesLeasedAndAssignedWorkers(t *testing.T) {
+	pool := NewPool(time.Minute, 10)
+	monitor := NewMonitor(pool, time.Hour)
+	leased := newFakeParty(t, "party-a", serving)
+	assigned := newFakeParty(t, "party-b", serving)
+	monitor.Watch(leased.worker)
+	monitor.Watch(assigned.worker)
+	monitor.CheckAll()
+	pool.Assign("key-1", "party-b")
+
+	if got, err := tryLease(pool, Request{Owner: "s1", N: 1}); err != nil || len(got) != 1 || got[0] != "party-a" {
+		t.Fatalf("lease = %v, %v; want [party-a]", got, err)
+	}
+
+	leased.set(notServing)
+	assigned.set(notServing)
+	monitor.CheckAll()
+	if pool.Has("party-a") || pool.Has("party-b") {
+		t.Fatalf("workers that went back to NOT_SERVING are still in the pool: %v", pool.List())
+	}
+	if _, err := tryLease(pool, Request{Owner: "s2", N: 1, Candidates: []string{"party-b"}}); err == nil {
+		t.Fatal("assigned worker reporting NOT_SERVING was lent")
+	}
+	pool.Release("s1")
+	if _, err := tryLease(pool, Request{Owner: "s2", N: 1}); err == nil {
+		t.Fatal("released worker reporting NOT_SERVING was lent")
+	}
+
+	leased.set(serving)
+	assigned.set(serving)
+	monitor.CheckAll()
+	if got, err := tryLease(pool, Request{Owner: "s3", N: 1}); err != nil || len(got) != 1 || got[0] != "party-a" {
+		t.Fatalf("lease after recovery = %v, %v; want [party-a]", got, err)
+	}
+	if !pool.Assigned("party-b") {
+		t.Fatal("key assignment was lost while the worker was out of the pool")
+	}
+}
+
+// 주소가 바뀐 워커는 새 주소로 SERVING을 확인할 때까지 빌려주지 않고, Forget한 워커는 더 확인하지 않습니다.
+func TestMonitorWatchAndForget(t *testing.T) {
+	pool := NewPool(time.Minute, 10)
+	monitor := NewMonitor(pool, time.Hour)
+	before := newFakeParty(t, "party-a", serving)
+	monitor.Watch(before.worker)
+	monitor.CheckAll()
+
+	after := newFakeParty(t, "party-a", notServing)
+	monitor.Watch(after.worker)
+	if pool.Has("party-a") {
+		t.Fatal("moved worker stayed in the pool before its new address was checked")
+	}
+	monitor.CheckAll()
+	if pool.Has("party-a") {
+		t.Fatal("moved worker was added although its new address reports NOT_SERVING")
+	}
+	after.set(serving)
+	monitor.CheckAll()
+	if workers := pool.List(); len(workers) != 1 || workers[0] != after.worker {
+		t.Fatalf("pool = %v, want [%v]", workers, after.worker)
+	}
+
+	monitor.Forget("party-a")
+	if pool.Has("party-a") {
+		t.Fatal("forgotten worker is still in the pool")
+	}
+	monitor.CheckAll()
+	if pool.Has("party-a") {
+		t.Fatal("forgotten worker was checked again")
+	}
+}
@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+)
+
+// Worker는 파티 프로세스 하나입니다. Kubernetes에서는 Pod, 로컬 실행에서는 party 프로세스입니다.
+// Name은 파티 이름으로, 파티가 자신의 이름으로 사용하는 값과 같아야 합니다.
+type Worker struct {
+	Name string
+	IP   string
+	Port int32
+}
+
+// Address는 워커의 gRPC 주소입니다.
+func (w Worker) Address() string {
+	return fmt.Sprintf("%s:%d", w.IP, w.Port)
+}
+
+// Orchestrator는 파티 워커를 만들고 삭제하며, 대기 풀의 워커를 세션에 빌려줍니다.
+// 풀과 관련된 메서드는 구현이 임베드한 Pool이 제공합니다. 풀에는 헬스 체크에서 SERVING을 보고한 워커만 들어갑니다.
+type Orchestrator interface {
+	// Start는 이미 실행 중인 워커를 풀에 넣고, 설정된 수보다 적으면 워커를 더 만듭니다.
+	Start(ctx context.Context) error
+	// List는 오케스트레이터가 관리하는 모든 워커를 반환합니다.
+	List() []Worker
+	// Has는 워커가 풀에 있는지 확인합니다.
+	Has(name string) bool
+	// Create는 워커 n개를 만들고, 실행되면 반환합니다. 워커는 헬스 체크에서 SERVING을 보고하면 풀에 들어갑니다.
+	Create(ctx context.Context, n int) ([]Worker, error)
+	// Wait는 세션 req.Owner에게 워커를 빌려줍니다. 바로 빌릴 수 없으면 ctx가 끝날 때까지 대기열에서 기다립니다.
+	Wait(ctx context.Context, req Request) ([]Worker, error)
+	// QueuePosition은 세션 owner의 요청이 대기열에서 몇 번째인지 반환합니다. 기다리고 있지 않으면 0입니다.
+	QueuePosition(owner string) int
+	// LeaseIdle은 키가 배정되지 않은 쉬고 있는 워커를 최대 n개까지 빌려줍니다. 부족해도 실패하지 않습니다.
+	LeaseIdle(owner string, n int) []Worker
+	// Release는 세션 owner가 빌린 워커를 모두 풀에 돌려줍니다.
+	Release(owner string)
+	// Assign은 워커들이 키 조각을 보관한다고 기록합니다. 배정된 워커는 새 키 생성에 빌려주지 않습니다.
+	Assign(keyID string, names ...string)
+	// Unassign은 워커들이 더 이상 키 조각을 보관하지 않는다고 기록합니다.
+	Unassign(keyID string, names ...string)
+	// Stats는 상태별 워커 수를 반환합니다.
+	Stats() Stats
+	// Demand는 대기열에서 키가 배정되지 않은 워커를 기다리는 요청들이 필요한 워커 수의 합입니다.
+	Demand() int
+	// Delete는 워커를 중지하고 풀에서 뺍니다.
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,323 @@
+package orchestrator
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats는 풀의 워커 수와 대기열 길이입니다.
+// Idle은 새 키 생성에 빌려줄 수 있는 워커, Assigned는 키 조각을 보관해 그 키에만 쓰이는 쉬고 있는 워커,
+// Leased는 세션이 빌려 간 워커입니다.
+type Stats struct {
+	Total    int `json:"total"`
+	Idle     int `json:"idle"`
+	Assigned int `json:"assigned"`
+	Leased   int `json:"leased"`
+	// Queued는 워커를 기다리는 요청 수입니다.
+	Queued int `json:"queued"`
+}
+
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+// Pool은 오케스트레이터가 관리하는 워커와 워커의 임대 상태를 추적합니다.
+// 각 오케스트레이터 구현이 임베드해 Lease, Release, List 등을 그대로 제공합니다.
+//
+// 워커는 세션(owner)이 빌려 가고 세션이 끝나면 돌려줍니다. 돌려받지 못한 임대는 ttl이 지나면 만료되어
+// 세션이 비정상 종료되어도 워커가 풀로 돌아옵니다. 키 조각을 보관한 워커는 그 키에 배정되어(sticky)
+// 새 키 생성에는 쓰이지 않고, 그 키의 서명과 재공유에서 이름으로만 빌려줍니다.
+// 바로 빌릴 수 없는 요청은 대기열에서 기다립니다(Wait).
+type Pool struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	workers map[string]Worker
+	// order는 워커를 빌려줄 순서입니다. 돌려받은 워커는 뒤로 보내 고르게 사용합니다.
+	order  []string
+	leases map[string]lease
+	keys   map[string]map[string]bool
+
+	maxQueue int
+	// waiters는 워커를 기다리는 요청으로, 워커를 받을 순서로 정렬되어 있습니다.
+	waiters []*waiter
+}
+
+// NewPool은 임대가 ttl 뒤에 만료되고, 최대 maxQueue개의 요청이 워커를 기다릴 수 있는 풀을 만듭니다.
+func NewPool(ttl time.Duration, maxQueue int) *Pool {
+	p := &Pool{
+		ttl:      ttl,
+		maxQueue: maxQueue,
+		workers:  make(map[string]Worker),
+		leases:   make(map[string]lease),
+		keys:     make(map[string]map[string]bool),
+	}
+	go p.expire()
+	return p
+}
+
+// Add는 워커를 풀에 추가합니다. 이미 있는 워커면 주소만 바꿉니다.
+// 빌려준 워커는 풀에서 빠졌다가 다시 추가되어도 돌려받기 전까지 다시 빌려주지 않습니다.
+func (p *Pool) Add(worker Worker) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.workers[worker.Name]; !ok {
+		p.order = append(p.order, worker.Name)
+	}
+	p.workers[worker.Name] = worker
+	p.dispatch()
+}
+
+// Has는 워커가 풀에 있는지 확인합니다.
+func (p *Pool) Has(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.workers[name]
+	return ok
+}
+
+// Remove는 워커를 풀에서 뺍니다. 임대와 키 배정은 그대로 둡니다.
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.workers, name)
+	p.order = removeName(p.order, name)
+}
+
+// List는 모든 워커를 반환합니다.
+func (p *Pool) List() []Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	workers := make([]Worker, 0, len(p.workers))
+	for _, name := range p.order {
+		workers = append(workers, p.workers[name])
+	}
+	return workers
+}
+
+// LeaseIdle은 키가 배정되지 않은 쉬고 있는 워커를 최대 n개까지 owner에게 빌려줍니다.
+// 오토스케일러가 지울 워커를 고를 때 사용하며, 부족해도 실패하지 않습니다.
+func (p *Pool) LeaseIdle(owner string, n int) []Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// 기다리는 요청이 있으면 쉬고 있는 워커는 그 요청의 몫입니다.
+	if len(p.waiters) > 0 {
+		return nil
+	}
+
+	return p.take(owner, p.idle(n))
+}
+
+// Release는 owner가 빌린 모든 워커를 돌려받습니다.
+func (p *Pool) Release(owner string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, l := range p.leases {
+		if l.owner == owner {
+			p.release(name)
+		}
+	}
+	p.dispatch()
+}
+
+// Held는 owner가 빌린 워커의 이름을 반환합니다.
+func (p *Pool) Held(owner string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var names []string
+	for name, l := range p.leases {
+		if l.owner == owner {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Assign은 워커들이 keyID의 키 조각을 보관한다고 기록합니다. 풀에 아직 없는 워커도 기록합니다.
+func (p *Pool) Assign(keyID string, names ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, name := range names {
+		if p.keys[name] == nil {
+			p.keys[name] = make(map[string]bool)
+		}
+		p.keys[name][keyID] = true
+	}
+}
+
+// Unassign은 워커들이 더 이상 keyID의 키 조각을 보관하지 않는다고 기록합니다.
+func (p *Pool) Unassign(keyID string, names ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, name := range names {
+		delete(p.keys[name], keyID)
+		if len(p.keys[name]) == 0 {
+			delete(p.keys, name)
+		}
+	}
+	p.dispatch()
+}
+
+// Keys는 워커가 키 조각을 보관한 키 ID를 정렬해 반환합니다.
+func (p *Pool) Keys(name string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keyIDs := make([]string, 0, len(p.keys[name]))
+	for keyID := range p.keys[name] {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	return keyIDs
+}
+
+// SetKeys는 워커가 키 조각을 보관한 키를 keyIDs로 바꿉니다. 다른 게이트웨이가 기록한 배정을 반영할 때 사용합니다.
+func (p *Pool) SetKeys(name string, keyIDs []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(keyIDs) == 0 {
+		delete(p.keys, name)
+	} else {
+		keys := make(map[string]bool, len(keyIDs))
+		for _, keyID := range keyIDs {
+			keys[keyID] = true
+		}
+		p.keys[name] = keys
+	}
+	p.dispatch()
+}
+
+// Assigned는 워커가 키 조각을 보관하는지 확인합니다. 풀에 없는 워커도 확인합니다.
+func (p *Pool) Assigned(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys[name]) > 0
+}
+
+// Holders는 키 조각을 보관한 워커의 이름을 반환합니다. 풀에 없는 워커도 포함합니다.
+func (p *Pool) Holders() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.keys))
+	for name := range p.keys {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Stats는 상태별 워커 수를 반환합니다.
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := Stats{Total: len(p.workers), Queued: len(p.waiters)}
+	for name := range p.workers {
+		switch {
+		case !p.free(name):
+			stats.Leased++
+		case len(p.keys[name]) > 0:
+			stats.Assigned++
+		default:
+			stats.Idle++
+		}
+	}
+	return stats
+}
+
+// free는 p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) free(name string) bool {
+	_, leased := p.leases[name]
+	return !leased
+}
+
+// idle은 키가 배정되지 않은 쉬고 있는 워커를 빌려줄 순서로 최대 n개 반환합니다.
+// p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) idle(n int) []string {
+	var names []string
+	for _, name := range p.order {
+		if len(names) == n {
+			break
+		}
+		if p.free(name) && len(p.keys[name]) == 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// freeAmong은 candidates 중 풀에 있는 쉬고 있는 워커를 candidates 순서로 최대 n개 반환합니다.
+// p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) freeAmong(candidates []string, n int) []string {
+	var names []string
+	for _, name := range candidates {
+		if len(names) == n {
+			break
+		}
+		if _, ok := p.workers[name]; ok && p.free(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// liveAmong은 candidates 중 풀에 있는 워커 수를 반환합니다. p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) liveAmong(candidates []string) int {
+	n := 0
+	for _, name := range candidates {
+		if _, ok := p.workers[name]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// take는 p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) take(owner string, names []string) []Worker {
+	expires := time.Now().Add(p.ttl)
+	workers := make([]Worker, len(names))
+	for i, name := range names {
+		workers[i] = p.workers[name]
+		p.leases[name] = lease{owner: owner, expires: expires}
+	}
+	return workers
+}
+
+// release는 p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) release(name string) {
+	delete(p.leases, name)
+	if _, ok := p.workers[name]; ok {
+		p.order = append(removeName(p.order, name), name)
+	}
+}
+
+func (p *Pool) expire() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		p.expireLeases(now)
+	}
+}
+
+// expireLeases는 now에 만료된 임대를 돌려받습니다.
+func (p *Pool) expireLeases(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, l := range p.leases {
+		if now.After(l.expires) {
+			log.Printf("Lease of %s held by %s expired", name, l.owner)
+			p.release(name)
+		}
+	}
+	p.dispatch()
+}
+
+func removeName(names []string, name string) []string {
+	for i, n := range names {
+		if n == name {
+			return append(names[:i:i], names[i+1:]...)
+		}
+	}
+	return names
+}
@@ -0,0 +1,240 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newTestPool은 workers를 순서대로 넣은 풀을 만듭니다.
+func newTestPool(workers ...string) *Pool {
+	p := NewPool(time.Minute, 10)
+	for i, name := range workers {
+		p.Add(Worker{Name: name, IP: "127.0.0.1", Port: int32(50061 + i)})
+	}
+	return p
+}
+
+// tryLease는 req의 워커를 빌리고, 빌리지 못하면 50ms 뒤에 포기합니다.
+func tryLease(p *Pool, req Request) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	workers, err := p.Wait(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return workerNames(workers), nil
+}
+
+func workerNames(workers []Worker) []string {
+	names := make([]string, len(workers))
+	for i, worker := range workers {
+		names[i] = worker.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestPoolLease(t *testing.T) {
+	tests := []struct {
+		name     string
+		workers  []string
+		assigned []string
+		// held는 다른 세션이 먼저 빌린 워커입니다.
+		held    []string
+		req     Request
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "idle workers in order",
+			workers: []string{"a", "b", "c"},
+			req:     Request{Owner: "s1", N: 2},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "leased workers are not lent twice",
+			workers: []string{"a", "b", "c"},
+			held:    []string{"a", "b"},
+			req:     Request{Owner: "s1", N: 2},
+			wantErr: ErrCapacityExhausted,
+		},
+		{
+			name:    "candidate leased by another session",
+			workers: []string{"a", "b"},
+			held:    []string{"a"},
+			req:     Request{Owner: "s1", N: 1, Candidates: []string{"a"}},
+			wantErr: ErrCapacityExhausted,
+		},
+		{
+			name:     "assigned workers are not lent for new keys",
+			workers:  []string{"a", "b", "c"},
+			assigned: []string{"a", "b"},
+			req:      Request{Owner: "s1", N: 1},
+			want:     []string{"c"},
+		},
+		{
+			name:     "not enough idle workers besides assigned ones",
+			workers:  []string{"a", "b", "c"},
+			assigned: []string{"a", "b"},
+			req:      Request{Owner: "s1", N: 2},
+			wantErr:  ErrCapacityExhausted,
+		},
+		{
+			name:     "candidates limited to holders",
+			workers:  []string{"a", "b", "c"},
+			assigned: []string{"a", "b"},
+			req:      Request{Owner: "s1", N: 2, Candidates: []string{"a", "b"}},
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "candidates not in the pool",
+			workers:  []string{"a", "c"},
+			assigned: []string{"a", "b"},
+			req:      Request{Owner: "s1", N: 2, Candidates: []string{"a", "b"}},
+			wantErr:  ErrCapacityExhausted,
+		},
+		{
+			name:     "all live candidates",
+			workers:  []string{"a", "b", "c", "d"},
+			assigned: []string{"a", "b", "c", "e"},
+			req:      Request{Owner: "s1", N: 2, Candidates: []string{"a", "b", "c", "e"}, All: true},
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "all live candidates waits for a leased candidate",
+			workers:  []string{"a", "b", "c"},
+			assigned: []string{"a", "b", "c"},
+			held:     []string{"c"},
+			req:      Request{Owner: "s1", N: 2, Candidates: []string{"a", "b", "c"}, All: true},
+			wantErr:  ErrCapacityExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPool(tt.workers...)
+			for _, name := range tt.assigned {
+				p.Assign("key-1", name)
+			}
+			if len(tt.held) > 0 {
+				if _, err := tryLease(p, Request{Owner: "other", N: len(tt.held), Candidates: tt.held}); err != nil {
+					t.Fatalf("lease %v to another session: %v", tt.held, err)
+				}
+			}
+
+			got, err := tryLease(p, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Wait returned %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("leased %v, want %v", got, tt.want)
+			}
+			if held := p.Held(tt.req.Owner); len(held) != len(tt.want) {
+				t.Fatalf("Held = %v, want %d workers", held, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestPoolRelease(t *testing.T) {
+	p := newTestPool("a", "b", "c")
+	if _, err := tryLease(p, Request{Owner: "s1", N: 2}); err != nil {
+		t.Fatalf("lease: %v", err)
+	}
+	if stats := p.Stats(); stats.Leased != 2 || stats.Idle != 1 {
+		t.Fatalf("Stats = %+v, want 2 leased and 1 idle", stats)
+	}
+
+	// 다른 세션의 Release는 s1의 워커를 돌려받지 않습니다.
+	p.Release("s2")
+	if stats := p.Stats(); stats.Leased != 2 {
+		t.Fatalf("Stats after releasing another session = %+v", stats)
+	}
+	p.Release("s1")
+	if stats := p.Stats(); stats.Leased != 0 || stats.Idle != 3 {
+		t.Fatalf("Stats after Release = %+v, want 3 idle", stats)
+	}
+
+	// 돌려받은 워커는 뒤로 보내므로 쉬고 있던 c를 먼저 빌려줍니다.
+	got, err := tryLease(p, Request{Owner: "s3", N: 1})
+	if err != nil || !slices.Equal(got, []string{"c"}) {
+		t.Fatalf("lease after Release = %v, %v; want [c]", got, err)
+	}
+}
+
+func TestPoolExpiresLeases(t *testing.T) {
+	p := newTestPool("a")
+	if _, err := tryLease(p, Request{Owner: "s1", N: 1}); err != nil {
+		t.Fatalf("lease: %v", err)
+	}
+
+	// ttl이 지나기 전에는 돌려받지 않습니다.
+	p.expireLeases(time.Now().Add(30 * time.Second))
+	if _, err := tryLease(p, Request{Owner: "s2", N: 1}); !errors.Is(err, ErrCapacityExhausted) {
+		t.Fatalf("lease before expiry returned %v, want ErrCapacityExhausted", err)
+	}
+
+	p.expireLeases(time.Now().Add(2 * time.Minute))
+	if held := p.Held("s1"); len(held) != 0 {
+		t.Fatalf("s1 still holds %v after its lease expired", held)
+	}
+	got, err := tryLease(p, Request{Owner: "s2", N: 1})
+	if err != nil || !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("lease after expiry = %v, %v; want [a]", got, err)
+	}
+}
+
+func TestPoolAssignAndUnassign(t *testing.T) {
+	p := newTestPool("a", "b")
+	p.Assign("key-2", "a")
+	p.Assign("key-1", "a", "b")
+	// 풀에 없는 워커도 배정을 기록합니다.
+	p.Assign("key-1", "c")
+
+	if keys := p.Keys("a"); !slices.Equal(keys, []string{"key-1", "key-2"}) {
+		t.Fatalf("Keys(a) = %v, want [key-1 key-2]", keys)
+	}
+	if stats := p.Stats(); stats.Assigned != 2 || stats.Idle != 0 {
+		t.Fatalf("Stats = %+v, want 2 assigned", stats)
+	}
+	if !p.Assigned("c") {
+		t.Fatal("worker c outside the pool is not assigned")
+	}
+
+	p.Unassign("key-1", "a", "b")
+	if !p.Assigned("a") || p.Assigned("b") {
+		t.Fatalf("after unassigning key-1: a assigned = %v, b assigned = %v; want true, false", p.Assigned("a"), p.Assigned("b"))
+	}
+	if stats := p.Stats(); stats.Assigned != 1 || stats.Idle != 1 {
+		t.Fatalf("Stats after Unassign = %+v, want 1 assigned and 1 idle", stats)
+	}
+
+	// 배정이 풀린 워커를 기다리던 요청이 워커를 받습니다.
+	done := make(chan []string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		workers, _ := p.Wait(ctx, Request{Owner: "s1", N: 2})
+		done <- workerNames(workers)
+	}()
+	waitQueued(t, p, "s1")
+	p.Unassign("key-2", "a")
+	if got := <-done; !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("waiter received %v, want [a b]", got)
+	}
+}
+
+// waitQueued는 owner의 요청이 대기열에 들어갈 때까지 기다립니다.
+func waitQueued(t *testing.T, p *Pool, owner string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for p.QueuePosition(owner) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("request of %s was not queued", owner)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
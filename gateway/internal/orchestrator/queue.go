@@ -0,0 +1,180 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrCapacityExhausted는 대기 기한까지 워커를 빌리지 못했을 때의 에러입니다.
+	ErrCapacityExhausted = errors.New("party capacity exhausted")
+	// ErrQueueFull은 대기열이 가득 차 요청을 받을 수 없을 때의 에러입니다.
+	ErrQueueFull = errors.New("party wait queue is full")
+)
+
+// Priority는 대기열에서의 우선순위입니다. 높은 우선순위의 요청이 먼저 워커를 받습니다.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// ParsePriority는 요청의 priority 값을 Priority로 바꿉니다. 비어 있으면 PriorityNormal입니다.
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "low":
+		return PriorityLow, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	}
+	return PriorityNormal, fmt.Errorf("unknown priority: %s", s)
+}
+
+// Request는 워커를 빌리려는 세션의 요청입니다.
+// Candidates가 비어 있으면 키가 배정되지 않은 쉬고 있는 워커 N개를, 있으면 그중 쉬고 있는 워커 N개를 빌립니다.
+// 키 조각을 보관한 파티로 서명하거나 재공유할 때 Candidates를 사용합니다.
+// All이 true이면 Candidates 중 풀에 있는 워커를 모두(최소 N개) 빌립니다. 재공유에서 조각을 가진 살아 있는 파티가
+// 모두 참여해 이전 세대의 조각을 지울 수 있도록 사용합니다.
+type Request struct {
+	Owner      string
+	N          int
+	Candidates []string
+	All        bool
+	Priority   Priority
+}
+
+type waiter struct {
+	req   Request
+	ready chan []Worker
+}
+
+// Wait는 req의 워커를 빌립니다. 지금 빌릴 수 없으면 대기열에서 ctx가 끝날 때까지 기다립니다.
+// 대기열은 우선순위, 같은 우선순위에서는 먼저 온 순서로 워커를 받습니다.
+// ctx의 기한이 지나면 ErrCapacityExhausted를, 대기열이 가득 차 있으면 ErrQueueFull을 반환합니다.
+func (p *Pool) Wait(ctx context.Context, req Request) ([]Worker, error) {
+	w := &waiter{req: req, ready: make(chan []Worker, 1)}
+
+	p.mu.Lock()
+	p.insertWaiter(w)
+	p.dispatch()
+	if len(w.ready) == 0 && len(p.waiters) > p.maxQueue {
+		p.removeWaiter(w)
+		p.dispatch()
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w: %d requests waiting", ErrQueueFull, p.maxQueue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case workers := <-w.ready:
+		return workers, nil
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	if !p.removeWaiter(w) {
+		// 기한과 동시에 워커를 받았다면 돌려줍니다.
+		for _, worker := range <-w.ready {
+			p.release(worker.Name)
+		}
+	}
+	// 앞에서 기다리던 요청이 빠졌으므로 뒤의 요청이 받을 수 있는지 다시 확인합니다.
+	p.dispatch()
+	p.mu.Unlock()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w: no %d free parties became available in time", ErrCapacityExhausted, req.N)
+	}
+	return nil, ctx.Err()
+}
+
+// QueuePosition은 owner의 요청이 대기열에서 몇 번째인지(1부터) 반환합니다. 기다리고 있지 않으면 0입니다.
+func (p *Pool) QueuePosition(owner string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, w := range p.waiters {
+		if w.req.Owner == owner {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Demand는 대기열에서 키가 배정되지 않은 워커를 기다리는 요청들이 필요한 워커 수의 합입니다.
+// 오토스케일러가 새로 만들 워커 수를 판단하는 데 사용합니다.
+func (p *Pool) Demand() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	demand := 0
+	for _, w := range p.waiters {
+		if len(w.req.Candidates) == 0 {
+			demand += w.req.N
+		}
+	}
+	return demand
+}
+
+// dispatch는 대기열 순서대로 빌릴 수 있게 된 요청에 워커를 빌려줍니다.
+// 키가 배정되지 않은 워커를 기다리는 요청은 앞의 요청이 받을 때까지 뒤의 요청이 가로채지 않습니다.
+// p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) dispatch() {
+	blocked := false
+	waiting := p.waiters[:0]
+	for _, w := range p.waiters {
+		var names []string
+		if len(w.req.Candidates) == 0 {
+			if !blocked {
+				names = p.idle(w.req.N)
+			}
+			if len(names) < w.req.N {
+				blocked = true
+				waiting = append(waiting, w)
+				continue
+			}
+		} else {
+			n := w.req.N
+			if w.req.All {
+				n = max(n, p.liveAmong(w.req.Candidates))
+			}
+			names = p.freeAmong(w.req.Candidates, n)
+			if len(names) < n {
+				waiting = append(waiting, w)
+				continue
+			}
+		}
+		w.ready <- p.take(w.req.Owner, names)
+	}
+	for i := len(waiting); i < len(p.waiters); i++ {
+		p.waiters[i] = nil
+	}
+	p.waiters = waiting
+}
+
+// insertWaiter는 w를 우선순위가 같거나 높은 요청들 뒤에 넣습니다.
+// p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) insertWaiter(w *waiter) {
+	i := sort.Search(len(p.waiters), func(i int) bool {
+		return p.waiters[i].req.Priority < w.req.Priority
+	})
+	p.waiters = append(p.waiters, nil)
+	copy(p.waiters[i+1:], p.waiters[i:])
+	p.waiters[i] = w
+}
+
+// removeWaiter는 w를 대기열에서 빼고, 대기열에 있었는지 반환합니다.
+// p.mu를 잡은 상태에서 호출해야 합니다.
+func (p *Pool) removeWaiter(w *waiter) bool {
+	for i, other := range p.waiters {
+		if other == w {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// queued는 대기열에 넣은 요청의 결과입니다.
+type queued struct {
+	owner   string
+	workers []Worker
+	err     error
+}
+
+// enqueue는 req를 백그라운드에서 기다리게 하고, 대기열에 들어간 뒤 반환합니다.
+func enqueue(t *testing.T, ctx context.Context, p *Pool, req Request, results chan<- queued) {
+	t.Helper()
+	go func() {
+		workers, err := p.Wait(ctx, req)
+		results <- queued{owner: req.Owner, workers: workers, err: err}
+	}()
+	waitQueued(t, p, req.Owner)
+}
+
+func TestQueueOrdersByPriorityThenArrival(t *testing.T) {
+	p := newTestPool("a")
+	if _, err := tryLease(p, Request{Owner: "busy", N: 1}); err != nil {
+		t.Fatalf("lease: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results := make(chan queued, 4)
+	enqueue(t, ctx, p, Request{Owner: "low", N: 1, Priority: PriorityLow}, results)
+	enqueue(t, ctx, p, Request{Owner: "normal-1", N: 1, Priority: PriorityNormal}, results)
+	enqueue(t, ctx, p, Request{Owner: "high", N: 1, Priority: PriorityHigh}, results)
+	enqueue(t, ctx, p, Request{Owner: "normal-2", N: 1, Priority: PriorityNormal}, results)
+
+	want := []string{"high", "normal-1", "normal-2", "low"}
+	for i, owner := range want {
+		if got := p.QueuePosition(owner); got != i+1 {
+			t.Fatalf("QueuePosition(%s) = %d, want %d", owner, got, i+1)
+		}
+	}
+	if got := p.QueuePosition("busy"); got != 0 {
+		t.Fatalf("QueuePosition of a request that is not waiting = %d, want 0", got)
+	}
+
+	// 워커 하나를 돌려줄 때마다 대기열의 맨 앞 요청이 받습니다.
+	holder := "busy"
+	for _, owner := range want {
+		p.Release(holder)
+		r := <-results
+		if r.err != nil || r.owner != owner {
+			t.Fatalf("worker went to %s (%v), want %s", r.owner, r.err, owner)
+		}
+		holder = owner
+	}
+}
+
+func TestQueueRejectsWhenFull(t *testing.T) {
+	p := NewPool(time.Minute, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results := make(chan queued, 1)
+	enqueue(t, ctx, p, Request{Owner: "s1", N: 1}, results)
+
+	_, err := p.Wait(ctx, Request{Owner: "s2", N: 1})
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Wait returned %v, want ErrQueueFull", err)
+	}
+	if got := p.QueuePosition("s2"); got != 0 {
+		t.Fatalf("rejected request is queued at %d", got)
+	}
+
+	// 바로 빌릴 수 있는 요청은 대기열이 가득 차도 받습니다.
+	p.Add(Worker{Name: "a"})
+	if r := <-results; r.err != nil || r.owner != "s1" {
+		t.Fatalf("queued request got %+v", r)
+	}
+	p.Add(Worker{Name: "b"})
+	if _, err := p.Wait(ctx, Request{Owner: "s3", N: 1}); err != nil {
+		t.Fatalf("Wait with a free worker: %v", err)
+	}
+}
+
+func TestQueueTimesOut(t *testing.T) {
+	p := newTestPool("a")
+	if _, err := tryLease(p, Request{Owner: "busy", N: 1}); err != nil {
+		t.Fatalf("lease: %v", err)
+	}
+
+	_, err := tryLease(p, Request{Owner: "s1", N: 1})
+	if !errors.Is(err, ErrCapacityExhausted) {
+		t.Fatalf("Wait returned %v, want ErrCapacityExhausted", err)
+	}
+	if got := p.QueuePosition("s1"); got != 0 {
+		t.Fatalf("timed out request is still queued at %d", got)
+	}
+
+	// 취소된 요청은 ErrCapacityExhausted가 아닌 취소 에러를 반환합니다.
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan queued, 1)
+	enqueue(t, ctx, p, Request{Owner: "s2", N: 1}, results)
+	cancel()
+	if r := <-results; !errors.Is(r.err, context.Canceled) {
+		t.Fatalf("canceled Wait returned %v, want context.Canceled", r.err)
+	}
+}
+
+// 앞의 요청이 워커를 기다리는 동안 뒤의 작은 요청이 워커를 가로채지 않고, 앞의 요청이 기한으로 빠지면 뒤의 요청이 받습니다.
+func TestQueueHeadOfLineAndDeparture(t *testing.T) {
+	p := newTestPool("a", "b")
+	if _, err := tryLease(p, Request{Owner: "busy", N: 1}); err != nil {
+		t.Fatalf("lease: %v", err)
+	}
+
+	results := make(chan queued, 2)
+	headCtx, cancelHead := context.WithCancel(context.Background())
+	enqueue(t, headCtx, p, Request{Owner: "head", N: 2}, results)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	enqueue(t, ctx, p, Request{Owner: "tail", N: 1}, results)
+	if got := p.Demand(); got != 3 {
+		t.Fatalf("Demand = %d, want 3", got)
+	}
+
+	cancelHead()
+	if r := <-results; r.owner != "head" || !errors.Is(r.err, context.Canceled) {
+		t.Fatalf("first result = %+v, want head canceled", r)
+	}
+	if r := <-results; r.owner != "tail" || r.err != nil || len(r.workers) != 1 {
+		t.Fatalf("second result = %+v, want tail with one worker", r)
+	}
+}
+
+// 많은 요청이 동시에 워커를 기다리고 돌려줘도 워커가 두 세션에 동시에 빌려지지 않습니다. -race로 실행합니다.
+func TestQueueConcurrentWaiters(t *testing.T) {
+	const workers, sessions = 3, 50
+	p := NewPool(time.Minute, sessions)
+	for i := 0; i < workers; i++ {
+		p.Add(Worker{Name: fmt.Sprintf("w%d", i)})
+	}
+
+	var mu sync.Mutex
+	inUse := make(map[string]string)
+	var wg sync.WaitGroup
+	errs := make(chan error, sessions)
+	for i := 0; i < sessions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			owner := fmt.Sprintf("s%d", i)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			leased, err := p.Wait(ctx, Request{Owner: owner, N: 1 + i%2, Priority: Priority(i % 3)})
+			if err != nil {
+				errs <- fmt.Errorf("%s: %v", owner, err)
+				return
+			}
+
+			mu.Lock()
+			for _, w := range leased {
+				if other, ok := inUse[w.Name]; ok {
+					errs <- fmt.Errorf("worker %s leased to %s and %s", w.Name, other, owner)
+				}
+				inUse[w.Name] = owner
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			for _, w := range leased {
+				delete(inUse, w.Name)
+			}
+			mu.Unlock()
+			p.Release(owner)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if stats := p.Stats(); stats.Leased != 0 || stats.Queued != 0 {
+		t.Fatalf("Stats after all sessions = %+v, want nothing leased or queued", stats)
+	}
+}
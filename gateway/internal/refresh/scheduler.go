@@ -0,0 +1,143 @@
+package refresh
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gateway/internal/registry"
+	"gateway/internal/reshare"
+)
+
+const (
+	// checkInterval은 갱신할 키를 찾는 주기입니다. 갱신 주기가 더 짧으면 갱신 주기를 사용합니다.
+	checkInterval = 10 * time.Minute
+	// persistentFailures번 연속으로 실패한 키는 실패가 계속된다고 따로 알립니다.
+	persistentFailures = 3
+)
+
+// refresher는 키 조각을 새로 고칩니다. reshare.Coordinator가 구현합니다.
+type refresher interface {
+	Refresh(keyID string) (*reshare.Result, error)
+}
+
+// Scheduler는 모든 키의 조각을 주기적으로 새로 고칩니다(proactive refresh).
+// 같은 파티와 같은 임계값으로 재공유하므로 공개키는 그대로이고, 이전 조각은 더 이상 쓸 수 없게 됩니다.
+// 마지막 갱신(또는 생성) 후 interval이 지난 키를 하나씩 차례로 갱신합니다.
+//
+// 갱신에 실패한 키는 확인 주기마다 다시 시도하지 않고, 확인 주기부터 두 배씩 늘려 interval까지 기다린 뒤 다시 시도합니다.
+// 보관 파티 하나가 풀에 없으면 그 키는 파티가 돌아올 때까지 갱신할 수 없으므로, 연속 실패가 persistentFailures번을 넘으면
+// 마지막 갱신 후 지난 시간과 함께 로그로 알립니다.
+type Scheduler struct {
+	keys     *registry.Registry
+	reshares refresher
+	interval time.Duration
+	// now는 현재 시각입니다. 테스트에서 바꿉니다.
+	now func() time.Time
+
+	// retries는 갱신에 실패한 키의 연속 실패 횟수와 다음 시도 시각입니다. 갱신 루프에서만 사용합니다.
+	retries map[string]*retry
+}
+
+type retry struct {
+	failures int
+	next     time.Time
+}
+
+func NewScheduler(keys *registry.Registry, reshares *reshare.Coordinator, interval time.Duration) *Scheduler {
+	return newScheduler(keys, reshares, interval)
+}
+
+func newScheduler(keys *registry.Registry, reshares refresher, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		keys:     keys,
+		reshares: reshares,
+		interval: interval,
+		now:      time.Now,
+		retries:  make(map[string]*retry),
+	}
+}
+
+// Start는 ctx가 끝날 때까지 백그라운드에서 갱신을 실행합니다. 시작하자마자 한 번 확인하므로
+// 게이트웨이가 갱신 주기보다 자주 재시작되어도 갱신이 밀리지 않습니다.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.tick())
+		defer ticker.Stop()
+		for {
+			s.refreshDue()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// tick은 갱신할 키를 찾는 주기입니다.
+func (s *Scheduler) tick() time.Duration {
+	return min(checkInterval, s.interval)
+}
+
+func (s *Scheduler) refreshDue() {
+	keys := s.keys.List(nil)
+	listed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		listed[key.ID] = true
+		last := key.CreatedAt
+		if key.RefreshedAt != nil {
+			last = *key.RefreshedAt
+		}
+		now := s.now()
+		if now.Sub(last) < s.interval {
+			continue
+		}
+		if r, ok := s.retries[key.ID]; ok && now.Before(r.next) {
+			continue
+		}
+
+		// 실패한 갱신은 준비된 조각을 버리므로 키는 이전 조각으로 계속 동작하며, 기다린 뒤 다시 시도합니다.
+		if _, err := s.reshares.Refresh(key.ID); err != nil {
+			s.failed(key.ID, last, err)
+			continue
+		}
+		delete(s.retries, key.ID)
+		log.Printf("Refreshed shares of key %s in %s", key.ID, s.now().Sub(now).Round(time.Millisecond))
+	}
+
+	// 지워진 키의 재시도 상태는 버립니다.
+	for keyID := range s.retries {
+		if !listed[keyID] {
+			delete(s.retries, keyID)
+		}
+	}
+}
+
+// failed는 키의 연속 실패를 기록하고 다음 시도 시각을 정합니다.
+func (s *Scheduler) failed(keyID string, last time.Time, err error) {
+	r, ok := s.retries[keyID]
+	if !ok {
+		r = &retry{}
+		s.retries[keyID] = r
+	}
+	r.failures++
+	now := s.now()
+	r.next = now.Add(s.backoff(r.failures))
+
+	if r.failures >= persistentFailures {
+		log.Printf("Refresh of key %s keeps failing (%d attempts, last refreshed %s ago), retrying in %s: %v",
+			keyID, r.failures, now.Sub(last).Round(time.Minute), r.next.Sub(now), err)
+		return
+	}
+	log.Printf("Failed to refresh shares of key %s, retrying in %s: %v", keyID, r.next.Sub(now), err)
+}
+
+// backoff는 failures번 연속으로 실패한 키를 다시 시도하기까지 기다리는 시간입니다.
+func (s *Scheduler) backoff(failures int) time.Duration {
+	wait := s.tick()
+	for i := 1; i < failures && wait < s.interval; i++ {
+		wait *= 2
+	}
+	return min(wait, s.interval)
+}
@@ -0,0 +1,175 @@
+package refresh
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"gateway/internal/registry"
+	"gateway/internal/reshare"
+	"gateway/internal/store"
+)
+
+// stubRefresher는 Refresh 호출을 기록하고, failing에 든 키는 실패시킵니다.
+type stubRefresher struct {
+	mu      sync.Mutex
+	calls   []string
+	failing map[string]bool
+	called  chan string
+}
+
+func (r *stubRefresher) Refresh(keyID string) (*reshare.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, keyID)
+	if r.called != nil {
+		r.called <- keyID
+	}
+	if r.failing[keyID] {
+		return nil, reshare.ErrHoldersUnavailable
+	}
+	return &reshare.Result{}, nil
+}
+
+// take는 지금까지의 Refresh 호출을 반환하고 기록을 비웁니다.
+func (r *stubRefresher) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.calls
+	r.calls = nil
+	return calls
+}
+
+// clock은 테스트가 직접 움직이는 시계입니다.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestScheduler(t *testing.T, interval time.Duration, keys ...*registry.Key) (*Scheduler, *stubRefresher, *clock) {
+	t.Helper()
+	reg, err := registry.New(store.NewMemory())
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+	for _, key := range keys {
+		if err := reg.Put(key); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	r := &stubRefresher{failing: make(map[string]bool)}
+	c := &clock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := newScheduler(reg, r, interval)
+	s.now = c.Now
+	return s, r, c
+}
+
+func TestSchedulerRefreshesDueKeys(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := base.Add(-time.Hour)
+	s, r, c := newTestScheduler(t, 24*time.Hour,
+		&registry.Key{ID: "old", CreatedAt: base.Add(-48 * time.Hour)},
+		&registry.Key{ID: "new", CreatedAt: base.Add(-time.Hour)},
+		&registry.Key{ID: "refreshed", CreatedAt: base.Add(-48 * time.Hour), RefreshedAt: &recent},
+	)
+
+	s.refreshDue()
+	if calls := r.take(); !slices.Equal(calls, []string{"old"}) {
+		t.Fatalf("refreshed %v, want [old]", calls)
+	}
+
+	// 하루가 지나면 나머지 키도 갱신할 때가 됩니다.
+	c.Advance(24 * time.Hour)
+	s.refreshDue()
+	calls := r.take()
+	slices.Sort(calls)
+	if !slices.Equal(calls, []string{"new", "old", "refreshed"}) {
+		t.Fatalf("refreshed %v after a day, want all keys", calls)
+	}
+}
+
+// 실패한 키는 확인 주기마다 다시 시도하지 않고, 기다리는 시간을 두 배씩 늘려 갱신 주기까지 기다립니다.
+func TestSchedulerBacksOffFailingKeys(t *testing.T) {
+	const interval = time.Hour
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, r, c := newTestScheduler(t, interval,
+		&registry.Key{ID: "broken", CreatedAt: base.Add(-2 * interval)},
+		&registry.Key{ID: "healthy", CreatedAt: base.Add(-2 * interval)},
+	)
+	r.failing["broken"] = true
+
+	s.refreshDue()
+	if calls := r.take(); len(calls) != 2 {
+		t.Fatalf("first pass refreshed %v, want both keys", calls)
+	}
+
+	// checkInterval(10분) 확인마다 broken의 시도 여부. 실패 후 10분, 20분, 40분, 60분(상한)을 기다립니다.
+	var attempts []time.Duration
+	for elapsed := checkInterval; elapsed <= 4*interval; elapsed += checkInterval {
+		c.Advance(checkInterval)
+		s.refreshDue()
+		if slices.Contains(r.take(), "broken") {
+			attempts = append(attempts, elapsed)
+		}
+	}
+	want := []time.Duration{10 * time.Minute, 30 * time.Minute, 70 * time.Minute, 130 * time.Minute, 190 * time.Minute}
+	if !slices.Equal(attempts, want) {
+		t.Fatalf("broken key was retried at %v, want %v", attempts, want)
+	}
+	if failures := s.retries["broken"].failures; failures != len(want)+1 {
+		t.Fatalf("recorded %d failures, want %d", failures, len(want)+1)
+	}
+	if _, ok := s.retries["healthy"]; ok {
+		t.Fatal("healthy key has retry state")
+	}
+
+	// 성공하면 재시도 상태를 지웁니다.
+	delete(r.failing, "broken")
+	c.Advance(interval)
+	s.refreshDue()
+	if calls := r.take(); !slices.Contains(calls, "broken") {
+		t.Fatalf("refreshed %v, want broken retried", calls)
+	}
+	if _, ok := s.retries["broken"]; ok {
+		t.Fatal("retry state was kept after a successful refresh")
+	}
+}
+
+// Start는 첫 주기를 기다리지 않고 바로 갱신할 키를 확인합니다.
+func TestSchedulerRefreshesOnStart(t *testing.T) {
+	s, r, _ := newTestScheduler(t, 24*time.Hour, &registry.Key{ID: "old", CreatedAt: time.Now().Add(-48 * time.Hour)})
+	s.now = time.Now
+	r.called = make(chan string, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	select {
+	case keyID := <-r.called:
+		if keyID != "old" {
+			t.Fatalf("refreshed %s, want old", keyID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not refresh the due key")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := newScheduler(nil, nil, 24*time.Hour)
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, checkInterval},
+		{2, 2 * checkInterval},
+		{4, 8 * checkInterval},
+		{20, 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := s.backoff(tt.failures); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
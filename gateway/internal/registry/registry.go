@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 키가 사용하는 곡선
+const (
+	CurveSecp256k1 = "secp256k1"
+	CurveEd25519   = "ed25519"
+)
+
+// Party는 키 조각을 보관하는 파티입니다.
+// Key는 hex로 인코딩된 tss-lib PartyID 키이며, 비어 있으면 파티 이름의 SHA-256을 사용합니다.
+// 키 생성으로 만든 키는 비어 있고, 재공유로 옮긴 키는 재공유 때 정한 키를 가집니다.
+type Party struct {
+	Name string `json:"name"`
+	IP   string `json:"ip"`
+	Port int32  `json:"port"`
+	Key  string `json:"key,omitempty"`
+}
+
+// Key는 게이트웨이가 관리하는 키의 메타데이터입니다.
+// Threshold는 tss-lib 임계값 t이며, 서명에는 t+1개의 파티가 필요합니다.
+// Generation은 파티들이 현재 사용하는 키 조각을 만든 키 생성 또는 재공유 세션의 ID입니다.
+// RefreshedAt은 마지막으로 같은 위원회 안에서 조각을 새로 고친 시각이며, 아직 없으면 비어 있습니다.
+// Labels는 키 생성을 요청한 쪽이 붙인 레이블로, 키 목록을 거르는 데 사용합니다.
+// Retired는 재공유로 위원회에서 빠졌지만 이전 세대의 조각을 아직 지우지 못한 파티입니다. 이전 세대의 조각도
+// 같은 비밀의 조각이므로, 지울 때까지 키에 배정해 두고 다른 키 생성에 빌려주지 않습니다.
+type Key struct {
+	ID          string            `json:"id"`
+	PublicKey   string            `json:"public_key"`
+	Curve       string            `json:"curve"`
+	Threshold   int               `json:"threshold"`
+	Parties     []Party           `json:"parties"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Generation  string            `json:"generation,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	RefreshedAt *time.Time        `json:"refreshed_at,omitempty"`
+	Retired     []Party           `json:"retired,omitempty"`
+}
+
+// Party는 이름이 name인 키 조각 보관 파티를 찾습니다.
+func (k *Key) Party(name string) (Party, bool) {
+	for _, party := range k.Parties {
+		if party.Name == name {
+			return party, true
+		}
+	}
+	return Party{}, false
+}
+
+// Matches는 키가 selector의 모든 레이블을 같은 값으로 가지고 있는지 확인합니다.
+func (k *Key) Matches(selector map[string]string) bool {
+	for name, value := range selector {
+		if v, ok := k.Labels[name]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// 레이블 이름과 값의 형식. Kubernetes 레이블과 같은 규칙을 따릅니다.
+var (
+	labelNamePattern  = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?)?$`)
+)
+
+const maxLabelLength = 63
+
+// ValidateLabels는 레이블 이름과 값이 허용된 형식인지 확인합니다.
+func ValidateLabels(labels map[string]string) error {
+	for name, value := range labels {
+		if len(name) > maxLabelLength || !labelNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid label name: %q", name)
+		}
+		if len(value) > maxLabelLength || !labelValuePattern.MatchString(value) {
+			return fmt.Errorf("invalid value for label %s: %q", name, value)
+		}
+	}
+	return nil
+}
+
+// ParseSelector는 "이름=값" 형식의 조건들을 레이블 selector로 바꿉니다.
+func ParseSelector(terms []string) (map[string]string, error) {
+	selector := make(map[string]string, len(terms))
+	for _, term := range terms {
+		name, value, ok := strings.Cut(term, "=")
+		if !ok {
+			return nil, fmt.Errorf("label selector must be name=value: %q", term)
+		}
+		selector[name] = value
+	}
+	if err := ValidateLabels(selector); err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
+
+// Backend는 키 메타데이터를 영구 저장합니다.
+type Backend interface {
+	PutKey(key *Key) error
+	ListKeys() ([]*Key, error)
+}
+
+// Registry는 생성된 키를 키 ID로 보관합니다.
+// 조회는 메모리에서 하고, 변경은 backend에 먼저 저장한 뒤 반영합니다.
+type Registry struct {
+	backend Backend
+
+	mu   sync.RWMutex
+	keys map[string]*Key
+}
+
+// New는 backend에 저장된 키를 불러와 레지스트리를 만듭니다.
+func New(backend Backend) (*Registry, error) {
+	keys, err := backend.ListKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys: %v", err)
+	}
+
+	r := &Registry{
+		backend: backend,
+		keys:    make(map[string]*Key, len(keys)),
+	}
+	for _, key := range keys {
+		r.keys[key.ID] = key
+	}
+	return r, nil
+}
+
+// Put은 키를 저장합니다. 저장에 실패하면 레지스트리는 바뀌지 않습니다.
+func (r *Registry) Put(key *Key) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.backend.PutKey(key); err != nil {
+		return fmt.Errorf("failed to save key %s: %v", key.ID, err)
+	}
+	r.keys[key.ID] = key
+	return nil
+}
+
+// List는 selector의 레이블을 모두 가진 키를 생성 시각 순서로 반환합니다.
+// selector가 비어 있으면 모든 키를 반환합니다.
+func (r *Registry) List(selector map[string]string) []*Key {
+	r.mu.RLock()
+	keys := make([]*Key, 0, len(r.keys))
+	for _, key := range r.keys {
+		if key.Matches(selector) {
+			keys = append(keys, key)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
+
+func (r *Registry) Get(id string) (*Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[id]
+	return key, ok
+}
@@ -0,0 +1,426 @@
+package reshare
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gateway/internal/config"
+	grpcClient "gateway/internal/grpc"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+)
+
+var (
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyBusy는 같은 키의 재공유가 이미 실행 중일 때의 에러입니다.
+	ErrKeyBusy = errors.New("key is already being reshared")
+	// ErrHoldersUnavailable은 갱신에 필요한 조각 보관 파티가 풀에 없을 때의 에러입니다.
+	ErrHoldersUnavailable = errors.New("key share holders are not in the pool")
+)
+
+// Result는 성공한 재공유의 결과입니다. Parties는 새 위원회 파티가 보고한 공개키 지문입니다.
+type Result struct {
+	Key     *registry.Key
+	Parties []session.PartyKey
+}
+
+// Coordinator는 키 조각 재공유를 실행합니다. 한 키에는 한 번에 하나의 재공유만 실행합니다.
+//
+// 새 위원회 파티는 새 조각을 준비만 해 두고, 모든 파티가 같은 공개키를 보고하면 키 메타데이터의
+// 세대를 새 세션으로 바꾼 뒤 CommitShare로 확정합니다. 메타데이터를 바꾸는 시점이 확정 시점이며,
+// 그 전에 실패하면 AbortShare로 준비된 조각을 버리므로 키는 기존 조각만으로 계속 동작합니다.
+// 확정 요청을 받지 못한 파티는 다음 서명이나 재공유에서 세대를 보고 스스로 확정합니다.
+//
+// 두 위원회의 파티는 재공유 세션 동안 오케스트레이터에서 빌리고, 끝나면 돌려줍니다.
+// 이전 세대의 조각도 같은 비밀의 조각이므로, 새 위원회에 없는 파티는 조각을 지운 뒤에만 키 배정을 풉니다.
+// 지우지 못한 파티는 키의 Retired에 남기고 Start의 주기 작업이 다시 지웁니다.
+type Coordinator struct {
+	keys        *registry.Registry
+	relayServer *grpcClient.RelayServer
+	orch        orchestrator.Orchestrator
+
+	mu   sync.Mutex
+	busy map[string]bool
+}
+
+func NewCoordinator(keys *registry.Registry, relayServer *grpcClient.RelayServer, orch orchestrator.Orchestrator) *Coordinator {
+	return &Coordinator{
+		keys:        keys,
+		relayServer: relayServer,
+		orch:        orch,
+		busy:        make(map[string]bool),
+	}
+}
+
+// Reshare는 키 조각을 대기 풀에서 빌린 m개의 파티로 이루어진 새 위원회로 옮기고 임계값을 threshold로 바꿉니다.
+// 기존 위원회는 조각을 가진 파티 중 풀에 있는 파티 모두(최소 t+1개)가 참여하므로, 일부 파티의 Pod을 잃은 키도 남은 파티로 옮길 수 있고
+// 참여한 파티의 이전 세대 조각은 재공유가 끝나면 바로 지웁니다.
+func (c *Coordinator) Reshare(keyID string, m, threshold int) (*Result, error) {
+	if !c.lock(keyID) {
+		return nil, ErrKeyBusy
+	}
+	defer c.unlock(keyID)
+
+	key, ok := c.keys.Get(keyID)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	sessionID := uuid.NewString()
+	defer c.orch.Release(sessionID)
+	oldParties, err := c.leaseHolders(sessionID, key, key.Threshold+1, true, orchestrator.PriorityNormal)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := c.wait(orchestrator.Request{Owner: sessionID, N: m, Priority: orchestrator.PriorityNormal})
+	if err != nil {
+		return nil, err
+	}
+	members := make([]registry.Party, len(workers))
+	for i, worker := range workers {
+		members[i] = registry.Party{Name: worker.Name, IP: worker.IP, Port: worker.Port}
+	}
+	return c.run(sessionID, key, oldParties, members, threshold, false)
+}
+
+// Refresh는 같은 파티, 같은 임계값으로 키 조각을 새로 고칩니다. 공개키는 바뀌지 않습니다.
+// 새 위원회가 조각을 가진 모든 파티이므로 모든 파티를 빌립니다.
+// 주기적인 작업이므로 파티가 부족하면 대기열에서 다른 요청보다 나중에 파티를 받습니다.
+func (c *Coordinator) Refresh(keyID string) (*Result, error) {
+	if !c.lock(keyID) {
+		return nil, ErrKeyBusy
+	}
+	defer c.unlock(keyID)
+
+	key, ok := c.keys.Get(keyID)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	// 갱신에는 모든 보관 파티가 필요합니다. 풀에 없는 파티가 있으면 대기열에서 기다리지 않고 바로 실패합니다.
+	var missing []string
+	for _, party := range key.Parties {
+		if !c.orch.Has(party.Name) {
+			missing = append(missing, party.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHoldersUnavailable, strings.Join(missing, ", "))
+	}
+
+	sessionID := uuid.NewString()
+	defer c.orch.Release(sessionID)
+	oldParties, err := c.leaseHolders(sessionID, key, len(key.Parties), false, orchestrator.PriorityLow)
+	if err != nil {
+		return nil, err
+	}
+	return c.run(sessionID, key, oldParties, oldParties, key.Threshold, true)
+}
+
+// leaseHolders는 키 조각을 가진 파티 중 풀에 있는(헬스 체크를 통과한) n개를 빌리고, 주소를 풀의 현재 주소로 바꾼 파티 목록을 반환합니다.
+// all이 true이면 풀에 있는 파티를 모두(최소 n개) 빌립니다.
+func (c *Coordinator) leaseHolders(sessionID string, key *registry.Key, n int, all bool, priority orchestrator.Priority) ([]registry.Party, error) {
+	names := make([]string, len(key.Parties))
+	for i, party := range key.Parties {
+		names[i] = party.Name
+	}
+	workers, err := c.wait(orchestrator.Request{Owner: sessionID, N: n, Candidates: names, All: all, Priority: priority})
+	if err != nil {
+		return nil, err
+	}
+	parties := make([]registry.Party, len(workers))
+	for i, worker := range workers {
+		party, _ := key.Party(worker.Name)
+		party.IP, party.Port = worker.IP, worker.Port
+		parties[i] = party
+	}
+	return parties, nil
+}
+
+// wait는 파티를 빌립니다. 파티가 부족하면 설정된 시간까지 대기열에서 기다립니다.
+func (c *Coordinator) wait(req orchestrator.Request) ([]orchestrator.Worker, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.Get().Pool.QueueTimeoutSeconds)*time.Second)
+	defer cancel()
+	return c.orch.Wait(ctx, req)
+}
+
+func (c *Coordinator) run(sessionID string, key *registry.Key, oldParties, members []registry.Party, threshold int, refresh bool) (*Result, error) {
+	// 두 위원회에 모두 속한 파티도 역할마다 다른 PartyID 키를 쓰도록 새 키는 세션 ID와 함께 만듭니다.
+	newParties := make([]registry.Party, len(members))
+	for i, member := range members {
+		newParties[i] = registry.Party{
+			Name: member.Name,
+			IP:   member.IP,
+			Port: member.Port,
+			Key:  partyKey(member.Name, sessionID),
+		}
+	}
+
+	oldPods, newPods := grpcClient.PodInfosOf(oldParties), grpcClient.PodInfosOf(newParties)
+
+	// 두 위원회에 모두 속한 파티에는 한 번만 요청합니다.
+	participants := make(map[string]registry.Party)
+	for _, party := range append(append([]registry.Party{}, oldParties...), newParties...) {
+		participants[party.Name] = party
+	}
+	names := make([]string, 0, len(participants))
+	for name := range participants {
+		names = append(names, name)
+	}
+	tokens, closeRelay, err := grpcClient.OpenRelaySession(c.relayServer, sessionID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRelay()
+	grpcClient.SetTokenHashes(tokens, oldPods, newPods)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var results []session.Result
+	var errs []string
+
+	for _, party := range participants {
+		wg.Add(1)
+		go func(party registry.Party) {
+			defer wg.Done()
+			resp, err := grpcClient.CallReshareService(address(party), sessionID, tokens[party.Name], key.ID, key.Generation, grpcClient.Curves[key.Curve],
+				oldPods, int32(key.Threshold), newPods, int32(threshold))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Failed to call reshare service on party %s: %v", party.Name, err)
+				errs = append(errs, fmt.Sprintf("%s: %v", party.Name, err))
+				return
+			}
+			if resp.Publickey != "" {
+				results = append(results, session.Result{Party: party.Name, PublicKey: resp.Publickey})
+			}
+		}(party)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		c.abort(key.ID, sessionID, newParties)
+		return nil, fmt.Errorf("resharing failed: %s", strings.Join(errs, "; "))
+	}
+
+	// 새 위원회의 모든 파티가 기존과 같은 공개키를 보고해야 합니다.
+	newNames := make([]string, len(newParties))
+	for i, party := range newParties {
+		newNames[i] = party.Name
+	}
+	publicKey, partyKeys, err := session.Agree(newNames, results)
+	if err == nil && publicKey != key.PublicKey {
+		err = &session.AgreementError{Reason: "reshared public key does not match the original key", Parties: partyKeys}
+	}
+	if err != nil {
+		c.abort(key.ID, sessionID, newParties)
+		return nil, err
+	}
+
+	// 키 메타데이터가 저장되면 키의 세대가 바뀌며, 이후의 서명은 새 조각으로만 실행됩니다.
+	// 저장하지 못하면 기존 세대가 그대로이므로 준비된 조각을 버립니다.
+	// 새 위원회에 없는 기존 파티는 조각을 지울 때까지 Retired에 남깁니다.
+	reshared := *key
+	reshared.Threshold = threshold
+	reshared.Parties = newParties
+	reshared.Generation = sessionID
+	reshared.Retired = nil
+	for _, party := range append(append([]registry.Party{}, key.Retired...), key.Parties...) {
+		if !containsParty(newParties, party.Name) && !containsParty(reshared.Retired, party.Name) {
+			reshared.Retired = append(reshared.Retired, party)
+		}
+	}
+	if refresh {
+		now := time.Now()
+		reshared.RefreshedAt = &now
+	}
+	if err := c.keys.Put(&reshared); err != nil {
+		c.abort(key.ID, sessionID, newParties)
+		return nil, err
+	}
+	log.Printf("Reshared key %s to %d-of-%d (generation %s)", key.ID, threshold+1, len(newParties), sessionID)
+
+	for _, party := range newParties {
+		if err := grpcClient.CallCommitShare(address(party), key.ID, sessionID); err != nil {
+			log.Printf("Failed to commit share of key %s on party %s: %v", key.ID, party.Name, err)
+		}
+	}
+
+	c.orch.Assign(key.ID, newNames...)
+
+	// 재공유에 참여한 파티는 빌린 주소로 바로 지웁니다. 참여하지 못한 파티의 주소는 다른 Pod이 쓰고 있을 수 있으므로
+	// 비워 두고, 풀에 돌아오면 그때의 주소로 지웁니다.
+	retired := reshared
+	retired.Retired = make([]registry.Party, len(reshared.Retired))
+	for i, party := range reshared.Retired {
+		if old, ok := findParty(oldParties, party.Name); ok {
+			retired.Retired[i] = old
+		} else {
+			party.IP, party.Port = "", 0
+			retired.Retired[i] = party
+		}
+	}
+	result := &reshared
+	if updated := c.deleteRetired(&retired); updated != nil {
+		result = updated
+	}
+	return &Result{Key: result, Parties: partyKeys}, nil
+}
+
+// Start는 ctx가 끝날 때까지 Retired 파티의 조각을 주기적으로 다시 지웁니다.
+func (c *Coordinator) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(retiredRetryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.retryRetired()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// retiredRetryInterval은 Retired 파티의 조각을 다시 지우는 주기입니다.
+const retiredRetryInterval = time.Minute
+
+// retryRetired는 Retired 파티가 있는 모든 키에서 풀에 돌아온 파티의 조각을 지웁니다.
+// 재공유 중인 키는 재공유가 끝난 뒤 다음 주기에 지웁니다.
+func (c *Coordinator) retryRetired() {
+	for _, key := range c.keys.List(nil) {
+		if len(key.Retired) == 0 || !c.lock(key.ID) {
+			continue
+		}
+		// 잠그기 전에 재공유가 끝났을 수 있으므로 다시 읽습니다.
+		if key, ok := c.keys.Get(key.ID); ok {
+			retired := *key
+			retired.Retired = make([]registry.Party, 0, len(key.Retired))
+			for _, party := range key.Retired {
+				if worker, ok := c.worker(party.Name); ok {
+					party.IP, party.Port = worker.IP, worker.Port
+				} else {
+					party.IP, party.Port = "", 0
+				}
+				retired.Retired = append(retired.Retired, party)
+			}
+			c.deleteRetired(&retired)
+		}
+		c.unlock(key.ID)
+	}
+}
+
+// deleteRetired는 key의 Retired 파티 중 주소가 있는 파티의 조각을 지우고, 지운 파티의 키 배정을 풉니다.
+// 지운 파티가 있으면 남은 Retired로 키를 저장하고 저장된 키를 반환하며, 없으면 nil을 반환합니다.
+// 지우지 못한 파티는 배정된 채로 남습니다.
+func (c *Coordinator) deleteRetired(key *registry.Key) *registry.Key {
+	var remaining []registry.Party
+	var deleted []string
+	for _, party := range key.Retired {
+		if party.IP == "" {
+			remaining = append(remaining, party)
+			continue
+		}
+		if err := grpcClient.CallDeleteShare(address(party), key.ID); err != nil {
+			log.Printf("Failed to delete share of key %s on retired party %s, retrying later: %v", key.ID, party.Name, err)
+			remaining = append(remaining, party)
+			continue
+		}
+		deleted = append(deleted, party.Name)
+	}
+	for _, party := range remaining {
+		if party.IP == "" {
+			log.Printf("Party %s still holds a share of key %s; deleting it once the party is back", party.Name, key.ID)
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	// 삭제를 기록하지 못하면 배정을 유지하고 다음 주기에 다시 지웁니다. DeleteShare는 여러 번 호출해도 됩니다.
+	updated := *key
+	updated.Retired = remaining
+	if err := c.keys.Put(&updated); err != nil {
+		log.Printf("Failed to record deleted shares of key %s: %v", key.ID, err)
+		return nil
+	}
+	c.orch.Unassign(key.ID, deleted...)
+	return &updated
+}
+
+// worker는 풀에 있는 워커 name을 찾습니다.
+func (c *Coordinator) worker(name string) (orchestrator.Worker, bool) {
+	if !c.orch.Has(name) {
+		return orchestrator.Worker{}, false
+	}
+	for _, worker := range c.orch.List() {
+		if worker.Name == name {
+			return worker, true
+		}
+	}
+	return orchestrator.Worker{}, false
+}
+
+// abort는 실패한 재공유로 준비된 조각을 버리도록 새 위원회 파티에 요청합니다.
+// 요청이 실패해도 준비된 조각은 확정되지 않으므로 사용되지 않고, 다음 재공유에서 덮어씁니다.
+func (c *Coordinator) abort(keyID, sessionID string, parties []registry.Party) {
+	for _, party := range parties {
+		if err := grpcClient.CallAbortShare(address(party), keyID, sessionID); err != nil {
+			log.Printf("Failed to abort staged share of key %s on party %s: %v", keyID, party.Name, err)
+		}
+	}
+}
+
+func (c *Coordinator) lock(keyID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.busy[keyID] {
+		return false
+	}
+	c.busy[keyID] = true
+	return true
+}
+
+func (c *Coordinator) unlock(keyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.busy, keyID)
+}
+
+// partyKey는 재공유에서 새 위원회 파티가 사용할 PartyID 키를 만듭니다.
+func partyKey(name, sessionID string) string {
+	sum := sha256.Sum256([]byte(name + "/" + sessionID))
+	return hex.EncodeToString(sum[:])
+}
+
+func address(party registry.Party) string {
+	return fmt.Sprintf("%s:%d", party.IP, party.Port)
+}
+
+func containsParty(parties []registry.Party, name string) bool {
+	_, ok := findParty(parties, name)
+	return ok
+}
+
+func findParty(parties []registry.Party, name string) (registry.Party, bool) {
+	for _, party := range parties {
+		if party.Name == name {
+			return party, true
+		}
+	}
+	return registry.Party{}, false
+}
@@ -0,0 +1,224 @@
+package reshare
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"gateway/internal/config"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/internal/store"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testPublicKey = "02aa"
+
+// fakeParty는 재공유 요청에 testPublicKey를 보고하고 받은 조각 삭제 요청을 기록하는 파티입니다.
+type fakeParty struct {
+	tssv1.UnimplementedKeygenServiceServer
+	name string
+
+	mu         sync.Mutex
+	reshared   bool
+	deleted    bool
+	failDelete bool
+}
+
+func (p *fakeParty) Reshare(_ context.Context, req *tssv1.ReshareRequest) (*tssv1.ReshareResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reshared = true
+	for _, pod := range req.NewPods {
+		if pod.Name == p.name {
+			return &tssv1.ReshareResponse{Publickey: testPublicKey}, nil
+		}
+	}
+	return &tssv1.ReshareResponse{}, nil
+}
+
+func (p *fakeParty) CommitShare(context.Context, *tssv1.CommitShareRequest) (*tssv1.CommitShareResponse, error) {
+	return &tssv1.CommitShareResponse{}, nil
+}
+
+func (p *fakeParty) DeleteShare(context.Context, *tssv1.DeleteShareRequest) (*tssv1.DeleteShareResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failDelete {
+		return nil, status.Error(codes.Unavailable, "share store unavailable")
+	}
+	p.deleted = true
+	return &tssv1.DeleteShareResponse{}, nil
+}
+
+func (p *fakeParty) state() (reshared, deleted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.reshared, p.deleted
+}
+
+// startParty는 파티를 루프백 주소의 임의 포트에서 실행하고 워커를 반환합니다.
+func startParty(t *testing.T, party *fakeParty) orchestrator.Worker {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	server := grpc.NewServer()
+	tssv1.RegisterKeygenServiceServer(server, party)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return orchestrator.Worker{Name: party.name, IP: "127.0.0.1", Port: int32(lis.Addr().(*net.TCPAddr).Port)}
+}
+
+// testOrchestrator는 워커를 만들거나 지우지 않고 풀만 제공합니다.
+type testOrchestrator struct {
+	*orchestrator.Pool
+}
+
+func (testOrchestrator) Start(context.Context) error { return nil }
+
+func (testOrchestrator) Create(context.Context, int) ([]orchestrator.Worker, error) {
+	return nil, errors.New("not supported")
+}
+
+func (testOrchestrator) Delete(context.Context, string) error { return nil }
+
+// 2-of-5 키를 새 위원회로 옮기면, 조각을 지우지 못한 기존 파티는 지울 때까지 키에 배정된 채로 남습니다.
+func TestReshareKeepsHoldersAssignedUntilSharesAreDeleted(t *testing.T) {
+	config.Get().Pool.QueueTimeoutSeconds = 5
+
+	pool := orchestrator.NewPool(time.Minute, 10)
+	orch := testOrchestrator{pool}
+	keys, err := registry.New(store.NewMemory())
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+
+	// old-1..old-3은 살아 있고, old-3은 조각 삭제에 실패합니다. old-4, old-5는 풀에 없습니다.
+	parties := map[string]*fakeParty{}
+	holders := make([]registry.Party, 0, 5)
+	for _, name := range []string{"old-1", "old-2", "old-3", "old-4", "old-5"} {
+		parties[name] = &fakeParty{name: name}
+		holders = append(holders, registry.Party{Name: name, IP: "192.0.2.1", Port: 50051})
+		pool.Assign("key-1", name)
+	}
+	parties["old-3"].failDelete = true
+	for _, name := range []string{"old-1", "old-2", "old-3"} {
+		pool.Add(startParty(t, parties[name]))
+	}
+	for _, name := range []string{"new-1", "new-2", "new-3"} {
+		parties[name] = &fakeParty{name: name}
+		pool.Add(startParty(t, parties[name]))
+	}
+	if err := keys.Put(&registry.Key{ID: "key-1", PublicKey: testPublicKey, Curve: registry.CurveSecp256k1, Threshold: 1, Parties: holders}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	c := NewCoordinator(keys, nil, orch)
+	result, err := c.Reshare("key-1", 3, 1)
+	if err != nil {
+		t.Fatalf("Reshare: %v", err)
+	}
+
+	// 살아 있는 기존 파티는 t+1개가 넘어도 모두 재공유에 참여합니다.
+	for _, name := range []string{"old-1", "old-2", "old-3"} {
+		if reshared, _ := parties[name].state(); !reshared {
+			t.Errorf("live holder %s did not take part in resharing", name)
+		}
+	}
+	tests := []struct {
+		name     string
+		deleted  bool
+		assigned bool
+	}{
+		{"old-1", true, false},
+		{"old-2", true, false},
+		{"old-3", false, true},
+		{"old-4", false, true},
+		{"old-5", false, true},
+		{"new-1", false, true},
+	}
+	for _, tt := range tests {
+		if _, deleted := parties[tt.name].state(); deleted != tt.deleted {
+			t.Errorf("share of %s deleted = %v, want %v", tt.name, deleted, tt.deleted)
+		}
+		if assigned := pool.Assigned(tt.name); assigned != tt.assigned {
+			t.Errorf("%s assigned = %v, want %v", tt.name, assigned, tt.assigned)
+		}
+	}
+	if got := retiredNames(result.Key); !slices.Equal(got, []string{"old-3", "old-4", "old-5"}) {
+		t.Fatalf("retired parties = %v, want [old-3 old-4 old-5]", got)
+	}
+
+	// old-3의 삭제가 성공하고 old-4가 풀에 돌아오면 다음 주기에 지웁니다.
+	parties["old-3"].mu.Lock()
+	parties["old-3"].failDelete = false
+	parties["old-3"].mu.Unlock()
+	pool.Add(startParty(t, parties["old-4"]))
+	c.retryRetired()
+
+	for _, name := range []string{"old-3", "old-4"} {
+		if _, deleted := parties[name].state(); !deleted {
+			t.Errorf("share of %s was not deleted on retry", name)
+		}
+		if pool.Assigned(name) {
+			t.Errorf("%s is still assigned after its share was deleted", name)
+		}
+	}
+	if !pool.Assigned("old-5") {
+		t.Error("old-5 was released while it still holds a share")
+	}
+	key, _ := keys.Get("key-1")
+	if got := retiredNames(key); !slices.Equal(got, []string{"old-5"}) {
+		t.Fatalf("retired parties after retry = %v, want [old-5]", got)
+	}
+}
+
+// 보관 파티가 풀에 없으면 갱신은 대기열에서 기다리지 않고 바로 실패합니다.
+func TestRefreshFailsWithoutAllHolders(t *testing.T) {
+	config.Get().Pool.QueueTimeoutSeconds = 5
+
+	pool := orchestrator.NewPool(time.Minute, 10)
+	keys, err := registry.New(store.NewMemory())
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+	holders := []registry.Party{{Name: "old-1"}, {Name: "old-2"}, {Name: "old-3"}}
+	for _, party := range holders {
+		pool.Assign("key-1", party.Name)
+	}
+	pool.Add(orchestrator.Worker{Name: "old-1"})
+	pool.Add(orchestrator.Worker{Name: "old-2"})
+	if err := keys.Put(&registry.Key{ID: "key-1", PublicKey: testPublicKey, Curve: registry.CurveSecp256k1, Threshold: 1, Parties: holders}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	start := time.Now()
+	_, err = NewCoordinator(keys, nil, testOrchestrator{pool}).Refresh("key-1")
+	if !errors.Is(err, ErrHoldersUnavailable) {
+		t.Fatalf("Refresh returned %v, want ErrHoldersUnavailable", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Refresh waited %s for the missing holder", elapsed)
+	}
+	if stats := pool.Stats(); stats.Leased != 0 || stats.Queued != 0 {
+		t.Fatalf("Stats after Refresh = %+v, want nothing leased or queued", stats)
+	}
+}
+
+func retiredNames(key *registry.Key) []string {
+	names := make([]string, len(key.Retired))
+	for i, party := range key.Retired {
+		names[i] = party.Name
+	}
+	return names
+}
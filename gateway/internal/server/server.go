@@ -1,8 +1,13 @@
 package server
 
 import (
+	"gateway/internal/autoscale"
 	grpcClient "gateway/internal/grpc"
 	"gateway/internal/handler"
+	"gateway/internal/job"
+	"gateway/internal/orchestrator"
+	"gateway/internal/registry"
+	"gateway/internal/reshare"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,13 +15,25 @@ import (
 type Server struct {
 	router       *gin.Engine
 	keygenServer *grpcClient.KeygenServiceServer
+	relayServer  *grpcClient.RelayServer
+	keys         *registry.Registry
+	reshares     *reshare.Coordinator
+	jobs         *job.Store
+	orch         orchestrator.Orchestrator
+	scaler       *autoscale.Scaler
 }
 
-func NewServer(keygenServer *grpcClient.KeygenServiceServer) *Server {
+func NewServer(keygenServer *grpcClient.KeygenServiceServer, relayServer *grpcClient.RelayServer, keys *registry.Registry, jobs *job.Store, reshares *reshare.Coordinator, orch orchestrator.Orchestrator, scaler *autoscale.Scaler) *Server {
 	router := gin.Default()
 	server := &Server{
 		router:       router,
 		keygenServer: keygenServer,
+		relayServer:  relayServer,
+		keys:         keys,
+		reshares:     reshares,
+		jobs:         jobs,
+		orch:         orch,
+		scaler:       scaler,
 	}
 
 	server.routes()
@@ -25,7 +42,14 @@ func NewServer(keygenServer *grpcClient.KeygenServiceServer) *Server {
 }
 
 func (s *Server) routes() {
-	s.router.POST("/keygen", handler.Keygen(s.keygenServer))
+	s.router.POST("/keygen", handler.Keygen(s.keygenServer, s.relayServer, s.keys, s.jobs, s.orch))
+	s.router.GET("/keygen/:id", handler.KeygenJob(s.keygenServer, s.jobs, s.orch))
+	s.router.POST("/sign", handler.Sign(s.keys, s.relayServer, s.orch))
+	s.router.GET("/keys", handler.ListKeys(s.keys))
+	s.router.GET("/keys/:id", handler.GetKey(s.keys))
+	s.router.POST("/keys/:id/reshare", handler.Reshare(s.keys, s.reshares))
+	s.router.GET("/pool", handler.PoolStats(s.orch))
+	s.router.GET("/admin/autoscale", handler.AutoscaleStatus(s.orch, s.scaler))
 }
 
 func (s *Server) Run(addr string) {
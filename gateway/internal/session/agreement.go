@@ -0,0 +1,73 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// PartyKey는 파티 하나가 보고한 공개키의 지문입니다. 키 생성 과정을 감사하는 데 사용합니다.
+// 보고하지 않은 파티의 Fingerprint는 비어 있습니다.
+type PartyKey struct {
+	Party       string `json:"party"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// AgreementError는 파티들이 같은 공개키를 보고하지 않았거나 일부 파티가 보고하지 않았을 때의 에러입니다.
+type AgreementError struct {
+	Reason  string
+	Parties []PartyKey
+}
+
+func (e *AgreementError) Error() string {
+	return e.Reason
+}
+
+// Fingerprint는 hex 공개키의 SHA-256 지문을 반환합니다.
+func Fingerprint(publicKey string) string {
+	b, err := hex.DecodeString(publicKey)
+	if err != nil {
+		b = []byte(publicKey)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Agree는 parties의 모든 파티가 같은 공개키를 보고했는지 확인하고 그 공개키를 반환합니다.
+// 파티별 지문은 성공과 실패에 관계없이 parties 순서로 반환합니다.
+func Agree(parties []string, results []Result) (string, []PartyKey, error) {
+	reported := make(map[string]string, len(results))
+	for _, result := range results {
+		reported[result.Party] = result.PublicKey
+	}
+
+	keys := make([]PartyKey, len(parties))
+	var missing []string
+	publicKey, mismatch := "", false
+	for i, party := range parties {
+		keys[i].Party = party
+		pub, ok := reported[party]
+		if !ok {
+			missing = append(missing, party)
+			continue
+		}
+		keys[i].Fingerprint = Fingerprint(pub)
+		if publicKey == "" {
+			publicKey = pub
+		} else if pub != publicKey {
+			mismatch = true
+		}
+	}
+
+	switch {
+	case mismatch:
+		return "", keys, &AgreementError{Reason: "parties reported different public keys", Parties: keys}
+	case len(missing) > 0:
+		return "", keys, &AgreementError{
+			Reason:  fmt.Sprintf("parties did not report a public key: %s", strings.Join(missing, ", ")),
+			Parties: keys,
+		}
+	}
+	return publicKey, keys, nil
+}
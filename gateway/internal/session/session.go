@@ -0,0 +1,259 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Result는 파티 하나가 GenerateKey 응답이나 KeygenFinished로 보고한 완료 결과입니다.
+type Result struct {
+	Party     string
+	PublicKey string
+}
+
+// Session은 하나의 키 생성 요청에 참여한 파티들의 완료 보고를 모읍니다.
+type Session struct {
+	ID      string
+	Parties []string
+	created time.Time
+
+	mu      sync.Mutex
+	results []Result
+	rounds  map[string]int
+	err     error
+	done    chan struct{}
+}
+
+// Wait는 모든 파티가 보고하거나, 세션이 실패하거나, ctx가 끝날 때까지 기다립니다.
+func (s *Session) Wait(ctx context.Context) ([]Result, error) {
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return s.Results(), fmt.Errorf("session %s: %v", s.ID, ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Result(nil), s.results...), s.err
+}
+
+// Results는 지금까지 받은 완료 보고를 반환합니다.
+func (s *Session) Results() []Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Result(nil), s.results...)
+}
+
+// Fail은 세션을 실패로 끝내고 기다리는 쪽을 깨웁니다.
+func (s *Session) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished() {
+		return
+	}
+	s.err = err
+	close(s.done)
+}
+
+func (s *Session) add(result Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished() {
+		return
+	}
+	if !s.member(result.Party) {
+		log.Printf("Session %s: ignoring report from non-participant %s", s.ID, result.Party)
+		return
+	}
+	// 같은 파티의 중복 보고(GenerateKey 응답과 KeygenFinished)는 한 번만 세고, 공개키가 다르면 세션을 실패로 끝냅니다.
+	for _, r := range s.results {
+		if r.Party != result.Party {
+			continue
+		}
+		if r.PublicKey != result.PublicKey {
+			s.err = &AgreementError{
+				Reason:  fmt.Sprintf("party %s reported different public keys", result.Party),
+				Parties: []PartyKey{{Party: r.Party, Fingerprint: Fingerprint(r.PublicKey)}, {Party: result.Party, Fingerprint: Fingerprint(result.PublicKey)}},
+			}
+			close(s.done)
+		}
+		return
+	}
+	s.results = append(s.results, result)
+	if len(s.results) == len(s.Parties) {
+		close(s.done)
+	}
+}
+
+// Round는 파티들이 보고한 라운드 중 가장 앞선 라운드를 반환합니다.
+// 아직 아무 파티도 라운드를 보고하지 않았다면 0입니다.
+func (s *Session) Round() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	round := 0
+	for _, r := range s.rounds {
+		if r > round {
+			round = r
+		}
+	}
+	return round
+}
+
+func (s *Session) progress(party string, round int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if round > s.rounds[party] {
+		s.rounds[party] = round
+	}
+}
+
+func (s *Session) member(party string) bool {
+	for _, p := range s.Parties {
+		if p == party {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Session) finished() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Record는 저장소에 남기는 세션 정보입니다.
+// 게이트웨이가 재시작했을 때 남아 있는 기록은 중단된 세션입니다.
+type Record struct {
+	ID        string
+	Parties   []string
+	CreatedAt time.Time
+}
+
+// Backend는 진행 중인 세션의 기록을 저장합니다.
+type Backend interface {
+	PutSession(record Record) error
+	DeleteSession(id string) error
+	ListSessions() ([]Record, error)
+}
+
+// Registry는 진행 중인 세션을 세션 ID로 관리합니다.
+// 닫히지 않은 채 maxAge가 지난 세션은 정리합니다.
+type Registry struct {
+	maxAge  time.Duration
+	backend Backend
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewRegistry는 backend에 남아 있는 세션을 중단된 세션으로 정리한 뒤 레지스트리를 만듭니다.
+// 세션의 파티 호출은 게이트웨이 프로세스에 묶여 있으므로 재시작 후에는 이어서 진행할 수 없습니다.
+func NewRegistry(maxAge time.Duration, backend Backend) (*Registry, error) {
+	records, err := backend.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sessions: %v", err)
+	}
+	for _, record := range records {
+		log.Printf("Session %s (%d parties, started %s) was interrupted by a restart", record.ID, len(record.Parties), record.CreatedAt.Format(time.RFC3339))
+		if err := backend.DeleteSession(record.ID); err != nil {
+			return nil, fmt.Errorf("failed to clean up session %s: %v", record.ID, err)
+		}
+	}
+
+	r := &Registry{
+		maxAge:   maxAge,
+		backend:  backend,
+		sessions: make(map[string]*Session),
+	}
+	go r.expire()
+	return r, nil
+}
+
+// Open은 parties의 모든 파티의 보고를 기다리는 세션을 등록합니다.
+func (r *Registry) Open(id string, parties []string) *Session {
+	s := &Session{
+		ID:      id,
+		Parties: parties,
+		created: time.Now(),
+		rounds:  make(map[string]int),
+		done:    make(chan struct{}),
+	}
+
+	// 기록은 재시작 후 중단된 세션을 알리는 용도이므로 저장에 실패해도 세션은 진행합니다.
+	if err := r.backend.PutSession(Record{ID: id, Parties: parties, CreatedAt: s.created}); err != nil {
+		log.Printf("Failed to record session %s: %v", id, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[id] = s
+	return s
+}
+
+// Close는 세션 등록을 해제합니다. 이후 도착한 보고는 버려집니다.
+func (r *Registry) Close(id string) {
+	r.mu.Lock()
+	delete(r.sessions, id)
+	r.mu.Unlock()
+	r.forget(id)
+}
+
+func (r *Registry) forget(id string) {
+	if err := r.backend.DeleteSession(id); err != nil {
+		log.Printf("Failed to delete session record %s: %v", id, err)
+	}
+}
+
+// Get은 진행 중인 세션을 반환합니다.
+func (r *Registry) Get(id string) (*Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	return s, ok
+}
+
+// Report는 파티의 완료 보고를 해당 세션에 전달합니다.
+func (r *Registry) Report(id string, result Result) error {
+	s, ok := r.Get(id)
+	if !ok {
+		return fmt.Errorf("unknown session: %s", id)
+	}
+	s.add(result)
+	return nil
+}
+
+// Progress는 파티가 보고한 라운드를 해당 세션에 기록합니다.
+func (r *Registry) Progress(id, party string, round int) error {
+	s, ok := r.Get(id)
+	if !ok {
+		return fmt.Errorf("unknown session: %s", id)
+	}
+	s.progress(party, round)
+	return nil
+}
+
+func (r *Registry) expire() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.mu.Lock()
+		for id, s := range r.sessions {
+			if time.Since(s.created) > r.maxAge {
+				log.Printf("Session %s expired", id)
+				s.Fail(fmt.Errorf("session %s expired", id))
+				delete(r.sessions, id)
+				r.forget(id)
+			}
+		}
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,51 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newTestSession(parties ...string) *Session {
+	return &Session{
+		ID:      "session-1",
+		Parties: parties,
+		rounds:  make(map[string]int),
+		done:    make(chan struct{}),
+	}
+}
+
+// 같은 파티가 GenerateKey 응답과 KeygenFinished로 같은 공개키를 보고하면 한 번만 셉니다.
+func TestSessionCountsDuplicateReportOnce(t *testing.T) {
+	s := newTestSession("party-a", "party-b")
+	s.add(Result{Party: "party-a", PublicKey: "02aa"})
+	s.add(Result{Party: "party-a", PublicKey: "02aa"})
+	if s.finished() {
+		t.Fatal("session finished before party-b reported")
+	}
+
+	s.add(Result{Party: "party-b", PublicKey: "02aa"})
+	results, err := s.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+}
+
+// 같은 파티가 서로 다른 공개키를 보고하면 세션이 합의 실패로 끝납니다.
+func TestSessionFailsOnConflictingReports(t *testing.T) {
+	s := newTestSession("party-a", "party-b")
+	s.add(Result{Party: "party-a", PublicKey: "02aa"})
+	s.add(Result{Party: "party-a", PublicKey: "03bb"})
+
+	_, err := s.Wait(context.Background())
+	var agreementErr *AgreementError
+	if !errors.As(err, &agreementErr) {
+		t.Fatalf("Wait returned %v, want an AgreementError", err)
+	}
+	if len(agreementErr.Parties) != 2 || agreementErr.Parties[0].Fingerprint == agreementErr.Parties[1].Fingerprint {
+		t.Fatalf("AgreementError parties = %+v, want both fingerprints of party-a", agreementErr.Parties)
+	}
+}
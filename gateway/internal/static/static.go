@@ -0,0 +1,69 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gateway/internal/config"
+	grpcClient "gateway/internal/grpc"
+	"gateway/internal/orchestrator"
+)
+
+// Orchestrator는 config.yaml에 나열된 파티를 사용합니다. Kubernetes 밖의 전용 호스트에서 실행되는
+// 파티를 위한 것으로, 파티를 만들거나 지우지 않습니다.
+// 명단의 모든 파티를 헬스 체크로 감시해 SERVING을 보고한 파티만 풀에 둡니다.
+type Orchestrator struct {
+	*orchestrator.Pool
+
+	parties []orchestrator.Worker
+	monitor *orchestrator.Monitor
+}
+
+// New는 명단을 읽고 TLS 파티의 자격 증명을 등록합니다.
+func New() (*Orchestrator, error) {
+	cfg := config.Get()
+
+	pool := orchestrator.NewPool(time.Duration(cfg.Pool.LeaseTimeoutSeconds)*time.Second, cfg.Pool.MaxQueueLength)
+	o := &Orchestrator{
+		Pool:    pool,
+		monitor: orchestrator.NewMonitor(pool, time.Duration(cfg.Orchestrator.HealthCheckSeconds)*time.Second),
+	}
+	for _, party := range cfg.Orchestrator.Static.Parties {
+		worker := orchestrator.Worker{Name: party.Name, IP: party.Address, Port: party.Port}
+		if party.TLS != nil {
+			serverName := party.TLS.ServerName
+			if serverName == "" {
+				serverName = party.Address
+			}
+			creds, err := grpcClient.LoadTLSCredentials(serverName, party.TLS.CAFile, party.TLS.CertFile, party.TLS.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("party %s: %v", party.Name, err)
+			}
+			grpcClient.SetPartyCredentials(worker.Address(), creds)
+		}
+		o.parties = append(o.parties, worker)
+	}
+	return o, nil
+}
+
+// Start는 명단의 파티를 한 번 확인한 뒤 백그라운드에서 주기적으로 헬스 체크를 합니다.
+func (o *Orchestrator) Start(ctx context.Context) error {
+	for _, party := range o.parties {
+		o.monitor.Watch(party)
+	}
+	o.monitor.CheckAll()
+	log.Printf("%d of %d static parties are serving", len(o.List()), len(o.parties))
+
+	go o.monitor.Run(ctx)
+	return nil
+}
+
+func (o *Orchestrator) Create(ctx context.Context, n int) ([]orchestrator.Worker, error) {
+	return nil, fmt.Errorf("static party roster cannot create parties; add them to orchestrator.static.parties")
+}
+
+func (o *Orchestrator) Delete(ctx context.Context, name string) error {
+	return fmt.Errorf("static party roster cannot delete party %s; remove it from orchestrator.static.parties", name)
+}
@@ -0,0 +1,102 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"gateway/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/yaml.v2"
+)
+
+// setRoster는 config.yaml의 orchestrator.static.parties를 roster로 바꿉니다.
+func setRoster(t *testing.T, roster string) {
+	t.Helper()
+	cfg := config.Get()
+	var parties []config.StaticParty
+	if err := yaml.Unmarshal([]byte(roster), &parties); err != nil {
+		t.Fatalf("yaml.Unmarshal: %v", err)
+	}
+	cfg.Orchestrator.Static.Parties = parties
+	cfg.Orchestrator.HealthCheckSeconds = 10
+	cfg.Pool.LeaseTimeoutSeconds = 60
+	cfg.Pool.MaxQueueLength = 10
+	t.Cleanup(func() { cfg.Orchestrator.Static.Parties = nil })
+}
+
+// startParty는 헬스 서비스만 제공하는 평문 파티를 실행하고 포트를 반환합니다.
+func startParty(t *testing.T, status healthpb.HealthCheckResponse_ServingStatus) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", status)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+// 명단의 파티 중 SERVING을 보고한 파티만 풀에 넣습니다.
+func TestStartAdmitsServingParties(t *testing.T) {
+	setRoster(t, fmt.Sprintf(`
+- {name: party-a, address: 127.0.0.1, port: %d}
+- {name: party-b, address: 127.0.0.1, port: %d}
+`, startParty(t, healthpb.HealthCheckResponse_SERVING), startParty(t, healthpb.HealthCheckResponse_NOT_SERVING)))
+
+	o, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if len(o.parties) != 2 || o.parties[0].Name != "party-a" || o.parties[1].Name != "party-b" {
+		t.Fatalf("roster = %v, want party-a and party-b", o.parties)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := o.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if workers := o.List(); len(workers) != 1 || workers[0] != o.parties[0] {
+		t.Fatalf("pool = %v, want [%v]", workers, o.parties[0])
+	}
+
+	// 정적 명단은 파티를 만들거나 지우지 않습니다.
+	if _, err := o.Create(ctx, 1); err == nil {
+		t.Fatal("Create succeeded")
+	}
+	if err := o.Delete(ctx, "party-a"); err == nil || !o.Has("party-a") {
+		t.Fatalf("Delete returned %v, want an error and party-a kept", err)
+	}
+}
+
+// TLS 자격 증명을 불러오지 못하면 어느 파티의 설정인지 알려 줍니다.
+func TestNewRejectsInvalidTLS(t *testing.T) {
+	missing := t.TempDir() + "/missing.pem"
+	tests := []struct {
+		name string
+		tls  string
+	}{
+		{"missing CA file", "{caFile: " + missing + "}"},
+		{"missing client certificate", "{certFile: " + missing + ", keyFile: " + missing + "}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRoster(t, `
+- {name: party-a, address: 10.0.0.11, port: 50051}
+- {name: party-b, address: 10.0.0.12, port: 50051, tls: `+tt.tls+`}
+`)
+			if _, err := New(); err == nil || !strings.HasPrefix(err.Error(), "party party-b:") {
+				t.Fatalf("New returned %v, want an error for party-b", err)
+			}
+		})
+	}
+}
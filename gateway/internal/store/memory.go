@@ -0,0 +1,96 @@
+package store
+
+import (
+	"sync"
+
+	"gateway/internal/job"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+)
+
+// Memory는 프로세스 메모리에만 저장하는 Store입니다. 재시작하면 모든 상태가 사라지므로
+// 테스트나 로컬 실행에 사용합니다.
+type Memory struct {
+	mu       sync.Mutex
+	keys     map[string]registry.Key
+	jobs     map[string]job.Job
+	sessions map[string]session.Record
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		keys:     make(map[string]registry.Key),
+		jobs:     make(map[string]job.Job),
+		sessions: make(map[string]session.Record),
+	}
+}
+
+func (m *Memory) PutKey(key *registry.Key) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[key.ID] = *key
+	return nil
+}
+
+func (m *Memory) ListKeys() ([]*registry.Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]*registry.Key, 0, len(m.keys))
+	for _, key := range m.keys {
+		key := key
+		keys = append(keys, &key)
+	}
+	return keys, nil
+}
+
+func (m *Memory) PutJob(j job.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[j.ID] = j
+	return nil
+}
+
+func (m *Memory) DeleteJob(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *Memory) ListJobs() ([]job.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]job.Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+func (m *Memory) PutSession(record session.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[record.ID] = record
+	return nil
+}
+
+func (m *Memory) DeleteSession(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *Memory) ListSessions() ([]session.Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records := make([]session.Record, 0, len(m.sessions))
+	for _, record := range m.sessions {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
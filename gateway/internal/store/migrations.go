@@ -0,0 +1,83 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// migrations는 SQLite 스키마 변경 목록입니다. i번째 항목이 버전 i+1이며 순서대로 한 번씩 적용합니다.
+// 이미 배포된 항목은 고치지 말고 새 항목을 뒤에 추가합니다.
+var migrations = []string{
+	// 1: 키, 작업, 세션 기록
+	`CREATE TABLE keys (
+		id           TEXT PRIMARY KEY,
+		public_key   TEXT NOT NULL,
+		curve        TEXT NOT NULL,
+		threshold    INTEGER NOT NULL,
+		parties      TEXT NOT NULL,
+		labels       TEXT NOT NULL DEFAULT '{}',
+		generation   TEXT NOT NULL DEFAULT '',
+		created_at   TEXT NOT NULL,
+		refreshed_at TEXT
+	);
+	CREATE TABLE jobs (
+		id         TEXT PRIMARY KEY,
+		state      TEXT NOT NULL,
+		session_id TEXT NOT NULL DEFAULT '',
+		key_id     TEXT NOT NULL DEFAULT '',
+		curve      TEXT NOT NULL DEFAULT '',
+		public_key TEXT NOT NULL DEFAULT '',
+		error_code TEXT NOT NULL DEFAULT '',
+		error      TEXT NOT NULL DEFAULT '',
+		parties    TEXT NOT NULL DEFAULT '[]',
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	);
+	CREATE TABLE sessions (
+		id         TEXT PRIMARY KEY,
+		parties    TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	);`,
+	// 2: 재공유로 위원회에서 빠졌지만 조각을 아직 지우지 못한 파티
+	`ALTER TABLE keys ADD COLUMN retired TEXT NOT NULL DEFAULT '[]';`,
+}
+
+// migrate는 아직 적용하지 않은 마이그레이션을 각각 하나의 트랜잭션으로 적용합니다.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this gateway (%d)", current, len(migrations))
+	}
+
+	for version := current + 1; version <= len(migrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[version-1]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %v", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, formatTime(time.Now())); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %v", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %v", version, err)
+		}
+		log.Printf("Applied database migration %d", version)
+	}
+	return nil
+}
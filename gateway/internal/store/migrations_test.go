@@ -0,0 +1,102 @@
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func schemaVersion(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		t.Fatalf("read schema version: %v", err)
+	}
+	return version
+}
+
+// 빈 데이터베이스에는 모든 마이그레이션을 적용하고, 다시 열면 아무것도 적용하지 않습니다.
+func TestMigrateFromEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "gateway.db")
+	s, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	if got := schemaVersion(t, s.db); got != len(migrations) {
+		t.Fatalf("schema version = %d, want %d", got, len(migrations))
+	}
+	for _, table := range []string{"keys", "jobs", "sessions"} {
+		var n int
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&n); err != nil {
+			t.Fatalf("table %s: %v", table, err)
+		}
+	}
+	s.Close()
+
+	s, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	var applied int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+	if applied != len(migrations) {
+		t.Fatalf("%d migrations recorded after reopening, want %d", applied, len(migrations))
+	}
+}
+
+// 이전 버전의 스키마에 저장된 키는 마이그레이션 뒤에도 불러올 수 있습니다.
+func TestMigrateKeepsExistingRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		t.Fatalf("create schema_migrations: %v", err)
+	}
+	if _, err := db.Exec(migrations[0]); err != nil {
+		t.Fatalf("apply migration 1: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (1, ?)`, formatTime(time.Now())); err != nil {
+		t.Fatalf("record migration 1: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO keys (id, public_key, curve, threshold, parties, created_at) VALUES ('key-1', '02aa', 'secp256k1', 1, '[{"name":"party-a"}]', ?)`, formatTime(time.Now())); err != nil {
+		t.Fatalf("insert key: %v", err)
+	}
+	db.Close()
+
+	s, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	defer s.Close()
+	keys, err := s.ListKeys()
+	if err != nil {
+		t.Fatalf("ListKeys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != "key-1" || len(keys[0].Parties) != 1 || len(keys[0].Retired) != 0 {
+		t.Fatalf("ListKeys after migration = %+v", keys)
+	}
+}
+
+// 이 게이트웨이보다 새 스키마의 데이터베이스는 열지 않습니다.
+func TestMigrateRejectsNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.db")
+	s, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	if _, err := s.db.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, len(migrations)+1, formatTime(time.Now())); err != nil {
+		t.Fatalf("record future migration: %v", err)
+	}
+	s.Close()
+
+	if s, err := OpenSQLite(path); err == nil {
+		s.Close()
+		t.Fatal("OpenSQLite accepted a database with a newer schema")
+	}
+}
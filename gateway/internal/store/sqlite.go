@@ -0,0 +1,210 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"gateway/internal/job"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+)
+
+// SQLite는 게이트웨이 상태를 SQLite 파일 하나에 저장하는 Store입니다.
+// 목록 필드(파티, 레이블)는 JSON으로 저장하고 시각은 RFC 3339 문자열로 저장합니다.
+type SQLite struct {
+	db *sql.DB
+}
+
+// OpenSQLite는 path의 데이터베이스를 열고 스키마를 최신 버전으로 마이그레이션합니다.
+func OpenSQLite(path string) (*SQLite, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create database dir: %v", err)
+	}
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %v", path, err)
+	}
+	// SQLite는 쓰기를 한 번에 하나만 처리하므로 연결 하나로 순서대로 실행합니다.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLite) PutKey(key *registry.Key) error {
+	parties, err := json.Marshal(key.Parties)
+	if err != nil {
+		return err
+	}
+	labels, err := json.Marshal(key.Labels)
+	if err != nil {
+		return err
+	}
+	retired, err := json.Marshal(key.Retired)
+	if err != nil {
+		return err
+	}
+	var refreshedAt sql.NullString
+	if key.RefreshedAt != nil {
+		refreshedAt = sql.NullString{String: formatTime(*key.RefreshedAt), Valid: true}
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO keys
+		(id, public_key, curve, threshold, parties, labels, generation, created_at, refreshed_at, retired)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key.ID, key.PublicKey, key.Curve, key.Threshold, string(parties), string(labels),
+		key.Generation, formatTime(key.CreatedAt), refreshedAt, string(retired))
+	return err
+}
+
+func (s *SQLite) ListKeys() ([]*registry.Key, error) {
+	rows, err := s.db.Query(`SELECT id, public_key, curve, threshold, parties, labels, generation, created_at, refreshed_at, retired FROM keys`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*registry.Key
+	for rows.Next() {
+		var key registry.Key
+		var parties, labels, createdAt, retired string
+		var refreshedAt sql.NullString
+		if err := rows.Scan(&key.ID, &key.PublicKey, &key.Curve, &key.Threshold, &parties, &labels,
+			&key.Generation, &createdAt, &refreshedAt, &retired); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(parties), &key.Parties); err != nil {
+			return nil, fmt.Errorf("key %s: invalid parties: %v", key.ID, err)
+		}
+		if err := json.Unmarshal([]byte(labels), &key.Labels); err != nil {
+			return nil, fmt.Errorf("key %s: invalid labels: %v", key.ID, err)
+		}
+		if err := json.Unmarshal([]byte(retired), &key.Retired); err != nil {
+			return nil, fmt.Errorf("key %s: invalid retired parties: %v", key.ID, err)
+		}
+		if key.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("key %s: %v", key.ID, err)
+		}
+		if refreshedAt.Valid {
+			t, err := parseTime(refreshedAt.String)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %v", key.ID, err)
+			}
+			key.RefreshedAt = &t
+		}
+		keys = append(keys, &key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *SQLite) PutJob(j job.Job) error {
+	parties, err := json.Marshal(j.Parties)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO jobs
+		(id, state, session_id, key_id, curve, public_key, error_code, error, parties, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		j.ID, j.State, j.SessionID, j.KeyID, j.Curve, j.PublicKey, j.ErrorCode, j.Error, string(parties),
+		formatTime(j.CreatedAt), formatTime(j.UpdatedAt))
+	return err
+}
+
+func (s *SQLite) DeleteJob(id string) error {
+	_, err := s.db.Exec(`DELETE FROM jobs WHERE id = ?`, id)
+	return err
+}
+
+func (s *SQLite) ListJobs() ([]job.Job, error) {
+	rows, err := s.db.Query(`SELECT id, state, session_id, key_id, curve, public_key, error_code, error, parties, created_at, updated_at FROM jobs`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []job.Job
+	for rows.Next() {
+		var j job.Job
+		var parties, createdAt, updatedAt string
+		if err := rows.Scan(&j.ID, &j.State, &j.SessionID, &j.KeyID, &j.Curve, &j.PublicKey, &j.ErrorCode, &j.Error,
+			&parties, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(parties), &j.Parties); err != nil {
+			return nil, fmt.Errorf("job %s: invalid parties: %v", j.ID, err)
+		}
+		if j.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("job %s: %v", j.ID, err)
+		}
+		if j.UpdatedAt, err = parseTime(updatedAt); err != nil {
+			return nil, fmt.Errorf("job %s: %v", j.ID, err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+func (s *SQLite) PutSession(record session.Record) error {
+	parties, err := json.Marshal(record.Parties)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO sessions (id, parties, created_at) VALUES (?, ?, ?)`,
+		record.ID, string(parties), formatTime(record.CreatedAt))
+	return err
+}
+
+func (s *SQLite) DeleteSession(id string) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE id = ?`, id)
+	return err
+}
+
+func (s *SQLite) ListSessions() ([]session.Record, error) {
+	rows, err := s.db.Query(`SELECT id, parties, created_at FROM sessions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []session.Record
+	for rows.Next() {
+		var record session.Record
+		var parties, createdAt string
+		if err := rows.Scan(&record.ID, &parties, &createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(parties), &record.Parties); err != nil {
+			return nil, fmt.Errorf("session %s: invalid parties: %v", record.ID, err)
+		}
+		if record.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("session %s: %v", record.ID, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %v", s, err)
+	}
+	return t, nil
+}
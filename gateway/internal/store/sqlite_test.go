@@ -0,0 +1,192 @@
+package store
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gateway/internal/job"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+)
+
+// openTestSQLite는 임시 디렉터리에 데이터베이스를 엽니다. 같은 path로 다시 열어 재시작을 흉내 낼 수 있습니다.
+func openTestSQLite(t *testing.T, path string) *SQLite {
+	t.Helper()
+	s, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func testKey(id string, createdAt time.Time, labels map[string]string) *registry.Key {
+	return &registry.Key{
+		ID:         id,
+		PublicKey:  "02" + id,
+		Curve:      registry.CurveSecp256k1,
+		Threshold:  1,
+		Parties:    []registry.Party{{Name: "party-a", IP: "10.0.0.1", Port: 50051}, {Name: "party-b", IP: "10.0.0.2", Port: 50051, Key: "ab"}},
+		Labels:     labels,
+		Generation: "session-" + id,
+		CreatedAt:  createdAt,
+	}
+}
+
+func TestSQLiteKeysRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.db")
+	s := openTestSQLite(t, path)
+
+	base := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	refreshed := base.Add(time.Hour)
+	keys := []*registry.Key{
+		testKey("key-1", base, map[string]string{"env": "dev", "team": "a"}),
+		testKey("key-2", base.Add(time.Second), map[string]string{"env": "prod"}),
+		testKey("key-3", base.Add(2*time.Second), map[string]string{"env": "dev"}),
+		testKey("key-4", base.Add(3*time.Second), nil),
+	}
+	keys[1].RefreshedAt = &refreshed
+	keys[2].Retired = []registry.Party{{Name: "party-c"}}
+	for _, key := range keys {
+		if err := s.PutKey(key); err != nil {
+			t.Fatalf("PutKey %s: %v", key.ID, err)
+		}
+	}
+	// 같은 ID로 다시 저장하면 덮어씁니다.
+	keys[0].Threshold = 2
+	if err := s.PutKey(keys[0]); err != nil {
+		t.Fatalf("PutKey overwrite: %v", err)
+	}
+	s.Close()
+
+	// 다시 열어 불러온 키는 저장한 키와 같습니다.
+	reg, err := registry.New(openTestSQLite(t, path))
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+	for _, want := range keys {
+		got, ok := reg.Get(want.ID)
+		if !ok {
+			t.Fatalf("key %s was not loaded", want.ID)
+		}
+		assertKeyEqual(t, got, want)
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     []string
+	}{
+		{"all keys by creation time", nil, []string{"key-1", "key-2", "key-3", "key-4"}},
+		{"one label", map[string]string{"env": "dev"}, []string{"key-1", "key-3"}},
+		{"every label must match", map[string]string{"env": "dev", "team": "a"}, []string{"key-1"}},
+		{"no match", map[string]string{"env": "staging"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, key := range reg.List(tt.selector) {
+				got = append(got, key.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("List(%v) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	// 페이지는 List의 순서로 나누므로, 같은 시각에 만든 키도 ID 순서로 항상 같은 자리에 옵니다.
+	tie := testKey("key-0", base.Add(3*time.Second), nil)
+	if err := reg.Put(tie); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	list := reg.List(nil)
+	if list[3].ID != "key-0" || list[4].ID != "key-4" {
+		t.Fatalf("keys created at the same time are ordered %s, %s; want key-0, key-4", list[3].ID, list[4].ID)
+	}
+}
+
+func assertKeyEqual(t *testing.T, got, want *registry.Key) {
+	t.Helper()
+	if !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Fatalf("key %s created_at = %v, want %v", want.ID, got.CreatedAt, want.CreatedAt)
+	}
+	if (got.RefreshedAt == nil) != (want.RefreshedAt == nil) || (got.RefreshedAt != nil && !got.RefreshedAt.Equal(*want.RefreshedAt)) {
+		t.Fatalf("key %s refreshed_at = %v, want %v", want.ID, got.RefreshedAt, want.RefreshedAt)
+	}
+	g, w := *got, *want
+	g.CreatedAt, w.CreatedAt = time.Time{}, time.Time{}
+	g.RefreshedAt, w.RefreshedAt = nil, nil
+	if len(w.Labels) == 0 {
+		g.Labels, w.Labels = nil, nil
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("key %s = %+v, want %+v", want.ID, g, w)
+	}
+}
+
+func TestSQLiteJobsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.db")
+	s := openTestSQLite(t, path)
+
+	now := time.Now()
+	want := job.Job{
+		ID:        "job-1",
+		State:     job.StateSucceeded,
+		SessionID: "session-1",
+		KeyID:     "key-1",
+		Curve:     registry.CurveSecp256k1,
+		PublicKey: "02aa",
+		Parties:   []session.PartyKey{{Party: "party-a", Fingerprint: "f1"}},
+		CreatedAt: now,
+		UpdatedAt: now.Add(time.Second),
+	}
+	if err := s.PutJob(want); err != nil {
+		t.Fatalf("PutJob: %v", err)
+	}
+	if err := s.PutJob(job.Job{ID: "job-2", State: job.StateFailed, ErrorCode: "ErrKeyAgreement", Error: "mismatch", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("PutJob: %v", err)
+	}
+	if err := s.DeleteJob("job-2"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	s.Close()
+
+	jobs, err := openTestSQLite(t, path).ListJobs()
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("ListJobs returned %d jobs, want 1", len(jobs))
+	}
+	got := jobs[0]
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("job times = %v, %v; want %v, %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+	got.CreatedAt, got.UpdatedAt = want.CreatedAt, want.UpdatedAt
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("job = %+v, want %+v", got, want)
+	}
+}
+
+func TestSQLiteSessionsRoundTrip(t *testing.T) {
+	s := openTestSQLite(t, filepath.Join(t.TempDir(), "gateway.db"))
+	now := time.Now()
+	for _, id := range []string{"session-1", "session-2"} {
+		if err := s.PutSession(session.Record{ID: id, Parties: []string{"party-a", "party-b"}, CreatedAt: now}); err != nil {
+			t.Fatalf("PutSession: %v", err)
+		}
+	}
+	if err := s.DeleteSession("session-1"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+
+	records, err := s.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "session-2" || !reflect.DeepEqual(records[0].Parties, []string{"party-a", "party-b"}) || !records[0].CreatedAt.Equal(now) {
+		t.Fatalf("ListSessions = %+v, want session-2 only", records)
+	}
+}
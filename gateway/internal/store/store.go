@@ -0,0 +1,16 @@
+package store
+
+import (
+	"gateway/internal/job"
+	"gateway/internal/registry"
+	"gateway/internal/session"
+)
+
+// Store는 게이트웨이가 재시작한 뒤에도 남아 있어야 하는 키, 작업, 세션 기록을 저장합니다.
+type Store interface {
+	registry.Backend
+	job.Backend
+	session.Backend
+
+	Close() error
+}
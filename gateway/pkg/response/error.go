@@ -13,8 +13,30 @@ const (
 	ErrKeyGeneration  = "ErrKeyGeneration"
 	ErrKeyMarshalling = "ErrKeyMarshalling"
 	ErrPodCreation    = "ErrPodCreation"
+
+	ErrInvalidSignRequest = "ErrInvalidSignRequest"
+	ErrKeyNotFound        = "ErrKeyNotFound"
+	ErrSigning            = "ErrSigning"
+
+	ErrJobNotFound    = "ErrJobNotFound"
+	ErrKeyAgreement   = "ErrKeyAgreement"
+	ErrJobInterrupted = "ErrJobInterrupted"
+
+	ErrInvalidReshareRequest = "ErrInvalidReshareRequest"
+	ErrResharing             = "ErrResharing"
+	ErrKeyBusy               = "ErrKeyBusy"
+
+	ErrInvalidKeyQuery = "ErrInvalidKeyQuery"
+
+	ErrCapacityExhausted = "ErrCapacityExhausted"
+	ErrQueueFull         = "ErrQueueFull"
+
+	ErrRequestCanceled = "ErrRequestCanceled"
 )
 
+// StatusClientClosedRequest는 응답하기 전에 클라이언트가 연결을 끊은 요청의 상태 코드입니다(nginx의 499).
+const StatusClientClosedRequest = 499
+
 // Error code to HTTP status code mapping
 var ErrorCodeToStatusCode = map[string]int{
 	ErrInvalidRequest: http.StatusBadRequest,
@@ -24,6 +46,25 @@ var ErrorCodeToStatusCode = map[string]int{
 	ErrKeyGeneration:  http.StatusInternalServerError,
 	ErrKeyMarshalling: http.StatusInternalServerError,
 	ErrPodCreation:    http.StatusInternalServerError,
+
+	ErrInvalidSignRequest: http.StatusBadRequest,
+	ErrKeyNotFound:        http.StatusNotFound,
+	ErrSigning:            http.StatusInternalServerError,
+
+	ErrJobNotFound:    http.StatusNotFound,
+	ErrKeyAgreement:   http.StatusInternalServerError,
+	ErrJobInterrupted: http.StatusInternalServerError,
+
+	ErrInvalidReshareRequest: http.StatusBadRequest,
+	ErrResharing:             http.StatusInternalServerError,
+	ErrKeyBusy:               http.StatusConflict,
+
+	ErrInvalidKeyQuery: http.StatusBadRequest,
+
+	ErrCapacityExhausted: http.StatusServiceUnavailable,
+	ErrQueueFull:         http.StatusTooManyRequests,
+
+	ErrRequestCanceled: StatusClientClosedRequest,
 }
 
 // Error code to message mapping
@@ -35,6 +76,25 @@ var ErrorCodeToMessage = map[string]string{
 	ErrKeyGeneration:  "키 생성 중 오류가 발생했습니다",
 	ErrKeyMarshalling: "공개 키 변환 중 오류가 발생했습니다",
 	ErrPodCreation:    "Pod 생성 중 오류가 발생했습니다",
+
+	ErrInvalidSignRequest: "서명 요청이 유효하지 않습니다",
+	ErrKeyNotFound:        "키를 찾을 수 없습니다",
+	ErrSigning:            "서명 프로세스 중 실패했습니다",
+
+	ErrJobNotFound:    "작업을 찾을 수 없습니다",
+	ErrKeyAgreement:   "파티들이 같은 공개키를 보고하지 않았습니다",
+	ErrJobInterrupted: "게이트웨이가 재시작되어 작업이 중단되었습니다",
+
+	ErrInvalidReshareRequest: "재공유 요청이 유효하지 않습니다",
+	ErrResharing:             "재공유 프로세스 중 실패했습니다",
+	ErrKeyBusy:               "키의 재공유가 이미 진행 중입니다",
+
+	ErrInvalidKeyQuery: "키 조회 조건이 유효하지 않습니다",
+
+	ErrCapacityExhausted: "대기 시간 안에 사용할 수 있는 파티가 생기지 않았습니다",
+	ErrQueueFull:         "파티를 기다리는 요청이 너무 많습니다",
+
+	ErrRequestCanceled: "클라이언트가 요청을 취소했습니다",
 }
 
 // const (
@@ -47,7 +107,5 @@ var ErrorCodeToMessage = map[string]string{
 // 	ErrMsgFailedDuringKeyGeneration    = "키 생성 중 실패했습니다"
 // 	ErrMsgInvalidRequestID             = "유효하지 않은 요청 ID입니다"
 // 	ErrMsgFailedConnectGRPC            = "gRPC 연결에 실패했습니다"
-// 	ErrMsgInvalidSignRequest           = "서명 요청이 유효하지 않습니다"
 // 	ErrMsgFailedStartSigning           = "서명 프로세스 시작에 실패했습니다"
-// 	ErrMsgFailedDuringSigning          = "서명 프로세스 중 실패했습니다"
 // )
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 
 	"party/internal/config"
 	"party/internal/grpc"
+	"party/internal/preparams"
+	"party/internal/store"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 func main() {
@@ -14,8 +21,50 @@ func main() {
 
 	cfg := config.Get()
 
-	server := grpc.NewServer()
+	// 키 조각은 KEK로 암호화해 디스크에 저장합니다.
+	kek, err := store.LoadKEK(cfg.ShareStore.KEK)
+	if err != nil {
+		log.Fatalf("Failed to load share encryption key: %v", err)
+	}
+	cipher, err := store.NewCipher(kek)
+	if err != nil {
+		log.Fatalf("Failed to create share cipher: %v", err)
+	}
+	shares, err := openShareStore(cipher)
+	if err != nil {
+		log.Fatalf("Failed to open share store: %v", err)
+	}
+
+	// 키 생성 지연이 소수 생성이 아닌 프로토콜에서만 생기도록 사전 파라미터를 미리 만듭니다.
+	pool := preparams.NewPool(cfg.PreParams.Dir, cfg.PreParams.PoolSize)
+	if err := pool.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start pre-params pool: %v", err)
+	}
+
+	server, err := grpc.NewServer(pool, shares)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC server: %v", err)
+	}
 	if err := server.Start(cfg.GRPC.Port); err != nil {
 		log.Fatalf("Failed to start gRPC server: %v", err)
 	}
 }
+
+// openShareStore는 설정된 종류의 키 조각 저장소를 엽니다.
+func openShareStore(cipher *store.Cipher) (store.Store, error) {
+	cfg := config.Get()
+	if cfg.ShareStore.Backend != config.ShareStoreSecret {
+		return store.NewFileStore(cfg.ShareStore.Dir, cipher)
+	}
+
+	// Secret 저장소는 클러스터 안에서 Pod의 서비스 계정으로 접근합니다.
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-cluster config: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return store.NewSecretStore(clientset, cfg.ShareStore.Namespace, cfg.Party.Name, cipher), nil
+}
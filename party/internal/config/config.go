@@ -3,18 +3,73 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"gopkg.in/yaml.v2"
 )
 
+// 키 조각 저장소 종류
+const (
+	ShareStoreFile   = "file"
+	ShareStoreSecret = "secret"
+)
+
+// 설정 파일보다 우선하는 환경 변수. 게이트웨이가 만든 Kubernetes Pod은 이미지의 설정 파일을 그대로 쓰고
+// 이 값들을 환경 변수로 받습니다.
+const (
+	// PartyNameEnv는 파티 이름(party.name)입니다. 키 조각 Secret의 이름에 들어가므로 Pod이 다시 만들어져도 바뀌지 않아야 합니다.
+	PartyNameEnv = "PARTY_NAME"
+	// ShareStoreEnv는 키 조각 저장소 종류(shareStore.backend)입니다.
+	ShareStoreEnv = "PARTY_SHARE_STORE"
+	// ShareNamespaceEnv는 secret 저장소의 네임스페이스(shareStore.namespace)입니다.
+	ShareNamespaceEnv = "PARTY_SHARE_NAMESPACE"
+	// GatewayHostEnv, GatewayPortEnv는 게이트웨이 gRPC 주소(gateway.host, gateway.port)입니다.
+	GatewayHostEnv = "PARTY_GATEWAY_HOST"
+	GatewayPortEnv = "PARTY_GATEWAY_PORT"
+)
+
 type Config struct {
 	GRPC struct {
 		Port int `yaml:"port"`
+		// TLS가 있으면 gRPC 서버를 TLS로 엽니다. 게이트웨이의 static 오케스트레이터로 전용 호스트에서 실행할 때 사용합니다.
+		TLS *struct {
+			CertFile string `yaml:"certFile"`
+			KeyFile  string `yaml:"keyFile"`
+			// ClientCAFile이 있으면 이 CA가 발급한 클라이언트 인증서(게이트웨이, 다른 파티)만 받습니다.
+			ClientCAFile string `yaml:"clientCAFile"`
+			// CAFile은 직접 통신(routing.mode: direct)에서 다른 파티의 서버 인증서를 검증하는 CA입니다. 비어 있으면 시스템 CA를 사용합니다.
+			// TLS 파티는 다른 파티에 TLS로 접속하며, certFile의 인증서를 클라이언트 인증서로 제시합니다.
+			CAFile string `yaml:"caFile"`
+		} `yaml:"tls"`
 	} `yaml:"grpc"`
 	Gateway struct {
 		Host string `yaml:"host"`
 		Port int    `yaml:"port"`
+		// TLS가 있으면 게이트웨이(중계 스트림, 완료와 진행 보고)에 TLS로 접속합니다. 게이트웨이의 grpc.tls와 함께 사용합니다.
+		TLS *struct {
+			// ServerName은 게이트웨이 인증서에 있어야 하는 이름입니다. 비어 있으면 host를 사용합니다.
+			ServerName string `yaml:"serverName"`
+			CAFile     string `yaml:"caFile"`
+			// CertFile, KeyFile은 게이트웨이가 클라이언트 인증서를 요구할 때 파티가 제시할 인증서입니다.
+			CertFile string `yaml:"certFile"`
+			KeyFile  string `yaml:"keyFile"`
+		} `yaml:"tls"`
 	} `yaml:"gateway"`
+	Party struct {
+		Name string `yaml:"name"`
+	} `yaml:"party"`
+	PreParams struct {
+		Dir      string `yaml:"dir"`
+		PoolSize int    `yaml:"poolSize"`
+	} `yaml:"preParams"`
+	ShareStore struct {
+		// Backend는 file(기본값) 또는 secret입니다.
+		Backend   string `yaml:"backend"`
+		Dir       string `yaml:"dir"`
+		Namespace string `yaml:"namespace"`
+		// KEK는 base64로 인코딩된 32바이트 키 암호화 키입니다. PARTY_SHARE_KEK 환경 변수가 우선합니다.
+		KEK string `yaml:"kek"`
+	} `yaml:"shareStore"`
 }
 
 var cfg Config
@@ -32,6 +87,56 @@ func Load() error {
 		return fmt.Errorf("error decoding config file: %v", err)
 	}
 
+	if name, ok := os.LookupEnv(PartyNameEnv); ok {
+		cfg.Party.Name = name
+	}
+	if backend, ok := os.LookupEnv(ShareStoreEnv); ok {
+		cfg.ShareStore.Backend = backend
+	}
+	if namespace, ok := os.LookupEnv(ShareNamespaceEnv); ok {
+		cfg.ShareStore.Namespace = namespace
+	}
+	if host, ok := os.LookupEnv(GatewayHostEnv); ok {
+		cfg.Gateway.Host = host
+	}
+	if value, ok := os.LookupEnv(GatewayPortEnv); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", GatewayPortEnv, err)
+		}
+		cfg.Gateway.Port = port
+	}
+
+	// 파티 이름이 없으면 호스트 이름(Pod 이름)을 사용합니다.
+	if cfg.Party.Name == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("error resolving party name: %v", err)
+		}
+		cfg.Party.Name = hostname
+	}
+
+	if cfg.PreParams.Dir == "" {
+		cfg.PreParams.Dir = "preparams"
+	}
+	if cfg.PreParams.PoolSize <= 0 {
+		cfg.PreParams.PoolSize = 2
+	}
+
+	switch cfg.ShareStore.Backend {
+	case "":
+		cfg.ShareStore.Backend = ShareStoreFile
+	case ShareStoreFile, ShareStoreSecret:
+	default:
+		return fmt.Errorf("unknown share store backend: %s", cfg.ShareStore.Backend)
+	}
+	if cfg.ShareStore.Dir == "" {
+		cfg.ShareStore.Dir = "shares"
+	}
+	if cfg.ShareStore.Namespace == "" {
+		cfg.ShareStore.Namespace = "default"
+	}
+
 	return nil
 }
 
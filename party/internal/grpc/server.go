@@ -1,23 +1,72 @@
 package grpc
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"log"
 	"net"
+	"os"
 
-	"party/internal/proto"
+	"party/internal/config"
+	"party/internal/preparams"
 	"party/internal/service"
+	"party/internal/store"
+	"party/internal/transport"
+	"proto/tss/v1"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Server struct {
 	keygenService *service.KeygenService
+	partyService  *transport.Server
+	preParams     *preparams.Pool
 }
 
-func NewServer() *Server {
+func NewServer(preParams *preparams.Pool, shares store.Store) (*Server, error) {
+	cfg := config.Get()
+	gatewayDial, peerDial, err := dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	router := transport.NewRouter()
+	relay := transport.NewRelay(fmt.Sprintf("%s:%d", cfg.Gateway.Host, cfg.Gateway.Port), cfg.Party.Name, router, gatewayDial)
 	return &Server{
-		keygenService: service.NewKeygenService(),
+		keygenService: service.NewKeygenService(transport.NewClient(peerDial), relay, router, preParams, shares, gatewayDial),
+		partyService:  transport.NewServer(router),
+		preParams:     preParams,
+	}, nil
+}
+
+// dialOptions는 게이트웨이와 다른 파티에 접속할 때의 전송 옵션을 반환합니다.
+// gateway.tls가 있으면 게이트웨이에, grpc.tls가 있으면(TLS 파티) 다른 파티에 TLS로 접속합니다.
+func dialOptions() (gateway, peer grpc.DialOption, err error) {
+	cfg := config.Get()
+	gateway, peer = grpc.WithInsecure(), grpc.WithInsecure()
+	if tlsConfig := cfg.Gateway.TLS; tlsConfig != nil {
+		serverName := tlsConfig.ServerName
+		if serverName == "" {
+			serverName = cfg.Gateway.Host
+		}
+		creds, err := clientCredentials(serverName, tlsConfig.CAFile, tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load gateway TLS credentials: %v", err)
+		}
+		gateway = grpc.WithTransportCredentials(creds)
+	}
+	if tlsConfig := cfg.GRPC.TLS; tlsConfig != nil {
+		// 다른 파티의 인증서는 접속하는 주소(IP 또는 호스트 이름)로 검증합니다.
+		creds, err := clientCredentials("", tlsConfig.CAFile, tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load party TLS credentials: %v", err)
+		}
+		peer = grpc.WithTransportCredentials(creds)
 	}
+	return gateway, peer, nil
 }
 
 func (s *Server) Start(port int) error {
@@ -26,8 +75,24 @@ func (s *Server) Start(port int) error {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterKeygenServiceServer(grpcServer, s.keygenService)
+	var opts []grpc.ServerOption
+	if tlsConfig := config.Get().GRPC.TLS; tlsConfig != nil {
+		creds, err := serverCredentials(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	tssv1.RegisterKeygenServiceServer(grpcServer, s.keygenService)
+	tssv1.RegisterPartyServiceServer(grpcServer, s.partyService)
+
+	// 사전 파라미터가 하나 이상 준비되어야 키 생성을 받을 준비가 된 것으로 알립니다.
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go reportReadiness(healthServer, s.preParams)
 
 	fmt.Printf("gRPC server listening on :%d\n", port)
 	if err := grpcServer.Serve(lis); err != nil {
@@ -36,3 +101,72 @@ func (s *Server) Start(port int) error {
 
 	return nil
 }
+
+// reportReadiness는 준비된 사전 파라미터가 있는 동안 SERVING을, 모두 소진되면 다시 준비될 때까지 NOT_SERVING을 보고합니다.
+// 게이트웨이는 NOT_SERVING을 보고한 파티를 풀에서 뺐다가 SERVING이 되면 다시 넣습니다.
+func reportReadiness(healthServer *health.Server, preParams *preparams.Pool) {
+	serving := false
+	for {
+		if ready := preParams.Len() > 0; ready != serving {
+			serving = ready
+			if serving {
+				log.Printf("Pre-params ready, party is serving")
+				healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+			} else {
+				log.Printf("Pre-params drained, party is not serving until a new set is generated")
+				healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+			}
+		}
+		<-preParams.Changed()
+	}
+}
+
+// serverCredentials는 gRPC 서버의 TLS 자격 증명을 만듭니다. clientCAFile이 있으면 클라이언트 인증서를 요구합니다.
+func serverCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// clientCredentials는 서버 인증서를 caFile로 검증하는 TLS 자격 증명을 만듭니다. caFile이 비어 있으면 시스템 CA를 사용합니다.
+// serverName이 비어 있으면 접속하는 주소로 검증하고, certFile과 keyFile이 있으면 클라이언트 인증서로 제시합니다(mTLS).
+func clientCredentials(serverName, caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
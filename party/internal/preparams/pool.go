@@ -0,0 +1,198 @@
+package preparams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+)
+
+// retryInterval은 사전 파라미터 생성이 실패했을 때 다시 시도하기까지의 대기 시간입니다.
+const retryInterval = 10 * time.Second
+
+// Pool은 ECDSA 키 생성에 필요한 Paillier 사전 파라미터(안전 소수)를 미리 만들어 둡니다.
+// 생성한 파라미터는 디스크에 저장하므로 재시작해도 다시 만들지 않습니다.
+// 파라미터에는 Paillier 비밀키가 들어 있으므로 한 번 사용한 파라미터는 삭제합니다.
+type Pool struct {
+	dir  string
+	size int
+
+	mu     sync.Mutex
+	sets   []entry
+	refill chan struct{}
+	// changed는 준비된 파라미터가 없어지거나 다시 생길 때 신호를 받습니다.
+	changed chan struct{}
+}
+
+type entry struct {
+	path   string
+	params *keygen.LocalPreParams
+}
+
+// NewPool은 dir에 size개의 사전 파라미터를 유지하는 풀을 만듭니다.
+func NewPool(dir string, size int) *Pool {
+	return &Pool{
+		dir:     dir,
+		size:    size,
+		refill:  make(chan struct{}, 1),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Start는 디스크에 저장된 파라미터를 불러오고, 부족한 만큼 백그라운드에서 생성합니다.
+func (p *Pool) Start(ctx context.Context) error {
+	if err := os.MkdirAll(p.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create pre-params dir: %v", err)
+	}
+	if err := p.load(); err != nil {
+		return err
+	}
+	log.Printf("Loaded %d pre-params sets from %s", p.Len(), p.dir)
+
+	go p.run(ctx)
+	return nil
+}
+
+// Changed는 준비된 파라미터가 모두 소진되거나, 소진된 뒤 다시 준비될 때 신호를 받는 채널을 반환합니다.
+// 신호를 받으면 Len으로 현재 상태를 확인합니다.
+func (p *Pool) Changed() <-chan struct{} {
+	return p.changed
+}
+
+// Len은 준비된 파라미터 수를 반환합니다.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sets)
+}
+
+// Take는 준비된 파라미터 하나를 꺼내고 디스크에서 삭제합니다.
+// 준비된 파라미터가 없으면 false를 반환하며, 이때 tss-lib가 키 생성 중에 직접 만듭니다.
+func (p *Pool) Take() (*keygen.LocalPreParams, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer p.signal()
+
+	if len(p.sets) == 0 {
+		return nil, false
+	}
+	e := p.sets[0]
+	p.sets = p.sets[1:]
+	if len(p.sets) == 0 {
+		notify(p.changed)
+	}
+	if err := os.Remove(e.path); err != nil {
+		log.Printf("Failed to remove used pre-params %s: %v", e.path, err)
+	}
+	return e.params, true
+}
+
+func (p *Pool) run(ctx context.Context) {
+	for {
+		for p.Len() < p.size {
+			start := time.Now()
+			params, err := keygen.GeneratePreParamsWithContext(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("Failed to generate pre-params: %v", err)
+				time.Sleep(retryInterval)
+				continue
+			}
+			if err := p.add(params); err != nil {
+				log.Printf("Failed to store pre-params: %v", err)
+				time.Sleep(retryInterval)
+				continue
+			}
+			log.Printf("Generated pre-params in %s (%d/%d ready)", time.Since(start).Round(time.Millisecond), p.Len(), p.size)
+		}
+
+		select {
+		case <-p.refill:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// add는 파라미터를 디스크에 저장한 뒤 풀에 넣습니다.
+// 쓰는 도중에 종료되어도 반쯤 쓰인 파일을 읽지 않도록 임시 파일에 쓰고 이름을 바꿉니다.
+func (p *Pool) add(params *keygen.LocalPreParams) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(p.dir, fmt.Sprintf("%d.json", time.Now().UnixNano()))
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sets = append(p.sets, entry{path: path, params: params})
+	if len(p.sets) == 1 {
+		notify(p.changed)
+	}
+	return nil
+}
+
+func (p *Pool) load() error {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read pre-params dir: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, name := range names {
+		path := filepath.Join(p.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read pre-params %s: %v", path, err)
+		}
+		var params keygen.LocalPreParams
+		if err := json.Unmarshal(data, &params); err != nil || !params.ValidateWithProof() {
+			log.Printf("Discarding invalid pre-params %s", path)
+			os.Remove(path)
+			continue
+		}
+		p.sets = append(p.sets, entry{path: path, params: &params})
+	}
+	if len(p.sets) > 0 {
+		notify(p.changed)
+	}
+	return nil
+}
+
+func (p *Pool) signal() {
+	notify(p.refill)
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,53 @@
+package preparams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+)
+
+func changed(p *Pool) bool {
+	select {
+	case <-p.Changed():
+		return true
+	case <-time.After(20 * time.Millisecond):
+		return false
+	}
+}
+
+// 파라미터가 모두 소진되거나 소진된 뒤 다시 준비되면 알리고, 개수만 바뀌면 알리지 않습니다.
+func TestPoolSignalsDrainAndRefill(t *testing.T) {
+	p := NewPool(t.TempDir(), 2)
+	for i := 0; i < 2; i++ {
+		if err := p.add(&keygen.LocalPreParams{}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if !changed(p) {
+		t.Fatal("no signal when the first set became ready")
+	}
+
+	if _, ok := p.Take(); !ok {
+		t.Fatal("Take returned no pre-params")
+	}
+	if changed(p) {
+		t.Fatal("signaled while a set was still ready")
+	}
+	if _, ok := p.Take(); !ok {
+		t.Fatal("Take returned no pre-params")
+	}
+	if !changed(p) || p.Len() != 0 {
+		t.Fatalf("no signal when the pool drained (%d ready)", p.Len())
+	}
+	if _, ok := p.Take(); ok {
+		t.Fatal("Take returned pre-params from a drained pool")
+	}
+
+	if err := p.add(&keygen.LocalPreParams{}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if !changed(p) || p.Len() != 1 {
+		t.Fatalf("no signal when the drained pool was refilled (%d ready)", p.Len())
+	}
+}
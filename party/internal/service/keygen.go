@@ -2,49 +2,217 @@ package service
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"math/big"
+	"sync"
 	"time"
 
 	"party/internal/config"
-	"party/internal/proto"
+	"party/internal/preparams"
+	"party/internal/store"
+	"party/internal/transport"
+	"party/internal/tss"
+	"proto/tss/v1"
 
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// progressTimeout은 진행 보고 하나를 보내는 데 기다리는 시간입니다.
+const progressTimeout = 10 * time.Second
+
 type KeygenService struct {
-	proto.UnimplementedKeygenServiceServer
+	tssv1.UnimplementedKeygenServiceServer
+	direct    *transport.Client
+	relay     *transport.Relay
+	router    *transport.Router
+	preParams *preparams.Pool
+	shares    store.Store
+	// gatewayDial은 게이트웨이에 완료와 진행을 보고할 때의 전송 옵션(평문 또는 TLS)입니다.
+	gatewayDial grpc.DialOption
+
+	// commitMu는 준비된 조각을 현재 조각으로 옮기는 동안 다른 확정, 폐기와 겹치지 않게 합니다.
+	commitMu sync.Mutex
 }
 
-func NewKeygenService() *KeygenService {
-	return &KeygenService{}
+func NewKeygenService(direct *transport.Client, relay *transport.Relay, router *transport.Router, preParams *preparams.Pool, shares store.Store, gatewayDial grpc.DialOption) *KeygenService {
+	return &KeygenService{
+		direct:      direct,
+		relay:       relay,
+		router:      router,
+		preParams:   preParams,
+		shares:      shares,
+		gatewayDial: gatewayDial,
+	}
 }
 
-func (s *KeygenService) GenerateKey(ctx context.Context, req *proto.KeygenRequest) (*proto.KeygenResponse, error) {
-	// 키 생성 작업 시뮬레이션
-	time.Sleep(2 * time.Second)
+// GenerateKey는 요청에 포함된 파티들과 요청한 곡선의 tss-lib 키 생성을 실행합니다.
+// N은 임계값(t), M은 참여 파티 수이며 Pods에는 자신을 포함한 모든 파티가 들어 있어야 합니다.
+func (s *KeygenService) GenerateKey(ctx context.Context, req *tssv1.KeygenRequest) (*tssv1.KeygenResponse, error) {
+	if req.SessionId == "" || req.KeyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id and key_id are required")
+	}
 
-	publicKey := fmt.Sprintf("generated_key_n%d_m%d", req.N, req.M)
+	threshold, partyCount := int(req.N), int(req.M)
+	if partyCount != len(req.Pods) {
+		return nil, status.Errorf(codes.InvalidArgument, "m (%d) does not match the number of pods (%d)", partyCount, len(req.Pods))
+	}
+	if threshold < 1 || threshold >= partyCount {
+		return nil, status.Errorf(codes.InvalidArgument, "n must be between 1 and %d", partyCount-1)
+	}
+
+	peerTransport, closeTransport := s.transportFor(ctx, req.SessionId, req.Routing)
+	defer closeTransport()
+	session, err := tss.NewSession(req.SessionId, config.Get().Party.Name, peersFromPods(req.Pods), peerTransport)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	session.OnRound = func(round int) {
+		s.reportProgress(req.SessionId, round)
+	}
+
+	// 다른 파티의 라운드 메시지를 이 세션으로 받습니다.
+	s.router.Register(req.SessionId, session, tokenHashes(req.Pods))
+	defer s.router.Unregister(req.SessionId)
+
+	curve := curveOf(req.Curve)
+	share, err := session.Keygen(ctx, curve, threshold, s.takePreParams(curve))
+	if err != nil {
+		log.Printf("Keygen failed for session %s: %v", req.SessionId, err)
+		return nil, status.Errorf(codes.Internal, "keygen failed: %v", err)
+	}
+
+	// 각 파티는 자신의 키 조각을 보관합니다. 저장하지 못한 파티는 완료를 보고하지 않습니다.
+	share.KeyID = req.KeyId
+	share.Generation = req.SessionId
+	if err := s.shares.Put(req.KeyId, share); err != nil {
+		log.Printf("Failed to store share for key %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "failed to store share")
+	}
+
+	pub, err := share.PublicKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	publicKey := hex.EncodeToString(pub)
 
 	// KeygenFinished 메시지를 Gateway로 보냅니다.
-	go s.sendKeygenFinished(publicKey)
+	go s.sendKeygenFinished(req.SessionId, publicKey)
+
+	return &tssv1.KeygenResponse{Publickey: publicKey}, nil
+}
+
+// takePreParams는 ECDSA 키 생성에 쓸 사전 파라미터를 풀에서 꺼냅니다.
+func (s *KeygenService) takePreParams(curve tss.Curve) *keygen.LocalPreParams {
+	if curve != tss.Secp256k1 {
+		return nil
+	}
+	params, ok := s.preParams.Take()
+	if !ok {
+		log.Printf("Pre-params pool is empty, generating pre-params during keygen")
+	}
+	return params
+}
+
+// transportFor는 게이트웨이가 지정한 라우팅 방식에 맞는 Transport와, 세션이 끝나면 호출할 해제 함수를 반환합니다.
+// 게이트웨이가 세션 요청에 붙여 보낸 토큰으로, relay 모드에서는 세션의 중계 스트림을 열고
+// direct 모드에서는 다른 파티에게 보내는 메시지에 붙여 발신자를 증명합니다.
+func (s *KeygenService) transportFor(ctx context.Context, sessionID string, mode tssv1.RoutingMode) (tss.Transport, func()) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(transport.TokenMetadata); len(values) > 0 {
+			token = values[0]
+		}
+	}
+	if mode != tssv1.RoutingMode_ROUTING_MODE_RELAY {
+		return s.direct.Session(token), func() {}
+	}
+	relay := s.relay.Session(sessionID, token)
+	return relay, relay.Close
+}
 
-	return &proto.KeygenResponse{Publickey: publicKey}, nil
+// tokenHashes는 세션 요청의 PodInfo에서 파티 이름별 세션 토큰 해시를 모읍니다. Router는 이 값으로 직접 받은 메시지의 발신자를 확인합니다.
+func tokenHashes(pods ...[]*tssv1.PodInfo) map[string][]byte {
+	hashes := make(map[string][]byte)
+	for _, list := range pods {
+		for _, pod := range list {
+			if len(pod.TokenHash) > 0 {
+				hashes[pod.Name] = pod.TokenHash
+			}
+		}
+	}
+	return hashes
+}
+
+func curveOf(curve tssv1.Curve) tss.Curve {
+	switch curve {
+	case tssv1.Curve_CURVE_SECP256K1:
+		return tss.Secp256k1
+	case tssv1.Curve_CURVE_ED25519:
+		return tss.Ed25519
+	}
+	return tss.Curve(curve.String())
+}
+
+func peersFromPods(pods []*tssv1.PodInfo) []tss.Peer {
+	peers := make([]tss.Peer, len(pods))
+	for i, pod := range pods {
+		peers[i] = tss.Peer{
+			Name:    pod.Name,
+			Address: fmt.Sprintf("%s:%d", pod.Ip, pod.Port),
+		}
+		if len(pod.PartyKey) > 0 {
+			peers[i].Key = new(big.Int).SetBytes(pod.PartyKey)
+		}
+	}
+	return peers
+}
+
+// reportProgress는 키 생성이 몇 번째 라운드에 있는지 게이트웨이에 알립니다.
+// 진행 보고는 상태 조회용이므로 실패해도 키 생성에는 영향을 주지 않습니다.
+func (s *KeygenService) reportProgress(sessionID string, round int) {
+	cfg := config.Get()
+	gatewayAddress := fmt.Sprintf("%s:%d", cfg.Gateway.Host, cfg.Gateway.Port)
+
+	conn, err := grpc.Dial(gatewayAddress, s.gatewayDial)
+	if err != nil {
+		log.Printf("Failed to connect to Gateway: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), progressTimeout)
+	defer cancel()
+
+	_, err = tssv1.NewKeygenServiceClient(conn).KeygenProgress(ctx, &tssv1.KeygenProgressRequest{
+		SessionId: sessionID,
+		Party:     cfg.Party.Name,
+		Round:     int32(round),
+	})
+	if err != nil {
+		log.Printf("Failed to report keygen progress for session %s: %v", sessionID, err)
+	}
 }
 
-func (s *KeygenService) sendKeygenFinished(publicKey string) {
+// sendKeygenFinished는 세션 ID와 파티 이름을 담아 게이트웨이에 키 생성 완료를 알립니다.
+func (s *KeygenService) sendKeygenFinished(sessionID, publicKey string) {
 	cfg := config.Get()
 	gatewayAddress := fmt.Sprintf("%s:%d", cfg.Gateway.Host, cfg.Gateway.Port)
 
 	// Gateway와의 gRPC 연결 설정
-	conn, err := grpc.Dial(gatewayAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(gatewayAddress, s.gatewayDial)
 	if err != nil {
 		log.Printf("Failed to connect to Gateway: %v", err)
 		return
 	}
 	defer conn.Close()
 
-	client := proto.NewKeygenServiceClient(conn)
+	client := tssv1.NewKeygenServiceClient(conn)
 
 	// KeygenFinished 스트림 시작
 	stream, err := client.KeygenFinished(context.Background())
@@ -54,8 +222,10 @@ func (s *KeygenService) sendKeygenFinished(publicKey string) {
 	}
 
 	// KeygenFinished 메시지 전송
-	err = stream.Send(&proto.KeygenFinishedRequest{
+	err = stream.Send(&tssv1.KeygenFinishedRequest{
 		Publickey: publicKey,
+		SessionId: sessionID,
+		Party:     cfg.Party.Name,
 	})
 	if err != nil {
 		log.Printf("Error sending KeygenFinished message: %v", err)
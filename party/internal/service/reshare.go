@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"log"
+
+	"party/internal/config"
+	"party/internal/store"
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reshare는 요청의 기존 위원회와 새 위원회 사이에 키 조각 재공유를 실행합니다.
+// 새 위원회에 속한 파티는 새 키 조각을 준비 자리에만 저장하고, 게이트웨이가 모든 파티의 성공을 확인한 뒤
+// CommitShare로 확정합니다. 그 전까지는 기존 조각으로 서명하므로 기존 조각과 새 조각이 섞이지 않습니다.
+// 기존 위원회에만 속한 파티의 조각은 게이트웨이가 재공유 성공을 확인한 뒤 DeleteShare로 삭제합니다.
+func (s *KeygenService) Reshare(ctx context.Context, req *tssv1.ReshareRequest) (*tssv1.ReshareResponse, error) {
+	if req.SessionId == "" || req.KeyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id and key_id are required")
+	}
+	oldThreshold, newThreshold := int(req.OldThreshold), int(req.NewThreshold)
+	if oldThreshold < 1 || oldThreshold >= len(req.OldPods) {
+		return nil, status.Errorf(codes.InvalidArgument, "old_threshold must be between 1 and %d", len(req.OldPods)-1)
+	}
+	if newThreshold < 1 || newThreshold >= len(req.NewPods) {
+		return nil, status.Errorf(codes.InvalidArgument, "new_threshold must be between 1 and %d", len(req.NewPods)-1)
+	}
+
+	peerTransport, closeTransport := s.transportFor(ctx, req.SessionId, req.Routing)
+	defer closeTransport()
+	reshare, err := tss.NewReshare(req.SessionId, config.Get().Party.Name, peersFromPods(req.OldPods), peersFromPods(req.NewPods), peerTransport)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var share *tss.KeyShare
+	if reshare.InOldCommittee() {
+		share, err = s.shares.Get(req.KeyId)
+		if err != nil && err != store.ErrNotFound {
+			log.Printf("Failed to load share for key %s: %v", req.KeyId, err)
+			return nil, status.Error(codes.Internal, "failed to load share")
+		}
+		// 서명과 마찬가지로 직전 재공유의 확정 요청을 받지 못했다면 여기서 확정합니다.
+		if req.Generation != "" && (share == nil || share.Generation != req.Generation) {
+			if share, err = s.commitShare(req.KeyId, req.Generation); err != nil {
+				return nil, err
+			}
+		}
+		if share == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "no share for key %s", req.KeyId)
+		}
+	}
+
+	curve := curveOf(req.Curve)
+	var preParams *keygen.LocalPreParams
+	if reshare.InNewCommittee() && curve == tss.Secp256k1 {
+		if preParams, err = s.requirePreParams(ctx); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to generate pre-params: %v", err)
+		}
+	}
+
+	s.router.Register(req.SessionId, reshare, tokenHashes(req.OldPods, req.NewPods))
+	defer s.router.Unregister(req.SessionId)
+
+	newShare, err := reshare.Run(ctx, share, curve, oldThreshold, newThreshold, preParams)
+	if err != nil {
+		log.Printf("Resharing failed for session %s: %v", req.SessionId, err)
+		return nil, status.Errorf(codes.Internal, "resharing failed: %v", err)
+	}
+	if newShare == nil {
+		return &tssv1.ReshareResponse{}, nil
+	}
+
+	newShare.KeyID = req.KeyId
+	newShare.Generation = req.SessionId
+	if err := s.shares.Put(store.StagedID(req.KeyId), newShare); err != nil {
+		log.Printf("Failed to stage reshared share for key %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "failed to store share")
+	}
+
+	pub, err := newShare.PublicKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &tssv1.ReshareResponse{Publickey: hex.EncodeToString(pub)}, nil
+}
+
+// CommitShare는 재공유로 준비된 조각을 현재 조각으로 확정합니다.
+// 이미 확정된 세대라면 아무것도 하지 않으므로 게이트웨이는 안전하게 다시 호출할 수 있습니다.
+func (s *KeygenService) CommitShare(ctx context.Context, req *tssv1.CommitShareRequest) (*tssv1.CommitShareResponse, error) {
+	if req.KeyId == "" || req.Generation == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id and generation are required")
+	}
+	if _, err := s.commitShare(req.KeyId, req.Generation); err != nil {
+		return nil, err
+	}
+	return &tssv1.CommitShareResponse{}, nil
+}
+
+// AbortShare는 실패한 재공유로 준비된 조각을 버립니다. 다른 세대의 준비된 조각은 건드리지 않습니다.
+func (s *KeygenService) AbortShare(ctx context.Context, req *tssv1.AbortShareRequest) (*tssv1.AbortShareResponse, error) {
+	if req.KeyId == "" || req.Generation == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id and generation are required")
+	}
+
+	s.commitMu.Lock()
+	defer s.commitMu.Unlock()
+
+	staged, err := s.shares.Get(store.StagedID(req.KeyId))
+	if err == store.ErrNotFound {
+		return &tssv1.AbortShareResponse{}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to load staged share for key %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "failed to load staged share")
+	}
+	if staged.Generation != req.Generation {
+		return &tssv1.AbortShareResponse{}, nil
+	}
+	if err := s.shares.Delete(store.StagedID(req.KeyId)); err != nil {
+		log.Printf("Failed to delete staged share for key %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "failed to delete staged share")
+	}
+	log.Printf("Discarded staged share for key %s (generation %s)", req.KeyId, req.Generation)
+	return &tssv1.AbortShareResponse{}, nil
+}
+
+// commitShare는 generation 세대의 조각을 현재 조각으로 만들고 그 조각을 반환합니다.
+// 준비된 조각을 현재 자리에 먼저 쓰고 나서 준비 자리를 지우므로, 중간에 멈춰도 다시 호출하면 이어서 확정됩니다.
+func (s *KeygenService) commitShare(keyID, generation string) (*tss.KeyShare, error) {
+	s.commitMu.Lock()
+	defer s.commitMu.Unlock()
+
+	active, err := s.shares.Get(keyID)
+	if err != nil && err != store.ErrNotFound {
+		log.Printf("Failed to load share for key %s: %v", keyID, err)
+		return nil, status.Error(codes.Internal, "failed to load share")
+	}
+	if active != nil && active.Generation == generation {
+		return active, nil
+	}
+
+	staged, err := s.shares.Get(store.StagedID(keyID))
+	if err == store.ErrNotFound || (err == nil && staged.Generation != generation) {
+		return nil, status.Errorf(codes.FailedPrecondition, "no share of generation %s for key %s", generation, keyID)
+	}
+	if err != nil {
+		log.Printf("Failed to load staged share for key %s: %v", keyID, err)
+		return nil, status.Error(codes.Internal, "failed to load staged share")
+	}
+
+	if err := s.shares.Put(keyID, staged); err != nil {
+		log.Printf("Failed to commit share for key %s: %v", keyID, err)
+		return nil, status.Error(codes.Internal, "failed to commit share")
+	}
+	if err := s.shares.Delete(store.StagedID(keyID)); err != nil {
+		log.Printf("Failed to delete staged share for key %s: %v", keyID, err)
+	}
+	log.Printf("Committed share for key %s (generation %s)", keyID, generation)
+	return staged, nil
+}
+
+// DeleteShare는 더 이상 이 파티가 보관하지 않아야 하는 키 조각을 삭제합니다.
+func (s *KeygenService) DeleteShare(ctx context.Context, req *tssv1.DeleteShareRequest) (*tssv1.DeleteShareResponse, error) {
+	if req.KeyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	for _, id := range []string{req.KeyId, store.StagedID(req.KeyId)} {
+		if err := s.shares.Delete(id); err != nil {
+			log.Printf("Failed to delete share %s: %v", id, err)
+			return nil, status.Error(codes.Internal, "failed to delete share")
+		}
+	}
+	log.Printf("Deleted share for key %s", req.KeyId)
+	return &tssv1.DeleteShareResponse{}, nil
+}
+
+// requirePreParams는 풀에서 사전 파라미터를 꺼내고, 풀이 비어 있으면 바로 만듭니다.
+// 재공유의 새 위원회는 tss-lib가 대신 만들어 주지 않으므로 반드시 필요합니다.
+func (s *KeygenService) requirePreParams(ctx context.Context) (*keygen.LocalPreParams, error) {
+	if params, ok := s.preParams.Take(); ok {
+		return params, nil
+	}
+	log.Printf("Pre-params pool is empty, generating pre-params for resharing")
+	return keygen.GeneratePreParamsWithContext(ctx)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"party/internal/config"
+	"party/internal/store"
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sign은 이 파티가 가진 키 조각으로 요청에 포함된 파티들과 서명을 실행합니다.
+// 서명 방식(ECDSA, EdDSA)은 키 조각의 곡선을 따릅니다.
+func (s *KeygenService) Sign(ctx context.Context, req *tssv1.SignRequest) (*tssv1.SignResponse, error) {
+	if req.SessionId == "" || req.KeyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id and key_id are required")
+	}
+	if len(req.Message) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "message must be a 32-byte hash, got %d bytes", len(req.Message))
+	}
+
+	share, err := s.shares.Get(req.KeyId)
+	if err != nil && err != store.ErrNotFound {
+		log.Printf("Failed to load share for key %s: %v", req.KeyId, err)
+		return nil, status.Error(codes.Internal, "failed to load share")
+	}
+
+	// 게이트웨이는 키의 현재 세대를 함께 보냅니다. 재공유 확정 요청을 받지 못한 파티는 여기서 확정하고,
+	// 그래도 세대가 다르면 서로 다른 세대의 조각이 섞이지 않도록 서명하지 않습니다.
+	if req.Generation != "" && (share == nil || share.Generation != req.Generation) {
+		if share, err = s.commitShare(req.KeyId, req.Generation); err != nil {
+			return nil, err
+		}
+	}
+	if share == nil {
+		return nil, status.Errorf(codes.NotFound, "no share for key %s", req.KeyId)
+	}
+
+	// 재공유로 받은 조각은 재공유 때의 PartyID 키로 서명해야 합니다.
+	peers := share.AssignKeys(peersFromPods(req.Pods))
+	peerTransport, closeTransport := s.transportFor(ctx, req.SessionId, req.Routing)
+	defer closeTransport()
+	session, err := tss.NewSession(req.SessionId, config.Get().Party.Name, peers, peerTransport)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.router.Register(req.SessionId, session, tokenHashes(req.Pods))
+	defer s.router.Unregister(req.SessionId)
+
+	signature, err := session.Sign(ctx, share, req.Message)
+	if err != nil {
+		log.Printf("Signing failed for session %s: %v", req.SessionId, err)
+		return nil, status.Errorf(codes.Internal, "signing failed: %v", err)
+	}
+
+	var recoveryID int32
+	if len(signature.SignatureRecovery) > 0 {
+		recoveryID = int32(signature.SignatureRecovery[0])
+	}
+
+	return &tssv1.SignResponse{
+		R:          signature.R,
+		S:          signature.S,
+		RecoveryId: recoveryID,
+		Signature:  signature.Signature,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// KEKEnv는 KEK를 설정 파일 대신 전달할 때 사용하는 환경 변수입니다. 설정 파일보다 우선합니다.
+const KEKEnv = "PARTY_SHARE_KEK"
+
+// sealVersion은 암호문 형식의 버전입니다. 형식: version(1) | nonce(12) | AES-256-GCM 암호문
+const sealVersion = 1
+
+// Cipher는 KEK(key-encryption key)로 키 조각을 AES-256-GCM 암호화합니다.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// LoadKEK는 환경 변수 또는 설정 값에서 base64로 인코딩된 32바이트 KEK를 읽습니다.
+func LoadKEK(configured string) ([]byte, error) {
+	encoded := configured
+	if env, ok := os.LookupEnv(KEKEnv); ok {
+		encoded = env
+	}
+	if encoded == "" {
+		return nil, fmt.Errorf("share encryption key is not set (shareStore.kek or %s)", KEKEnv)
+	}
+
+	kek, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("share encryption key is not valid base64: %v", err)
+	}
+	if len(kek) != 32 {
+		return nil, fmt.Errorf("share encryption key must be 32 bytes, got %d", len(kek))
+	}
+	return kek, nil
+}
+
+func NewCipher(kek []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+func (c *Cipher) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 1+len(nonce)+len(plaintext)+c.aead.Overhead())
+	out = append(out, sealVersion)
+	out = append(out, nonce...)
+	return c.aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+func (c *Cipher) Open(sealed, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < 1+nonceSize || sealed[0] != sealVersion {
+		return nil, errors.New("unsupported ciphertext format")
+	}
+	nonce, ciphertext := sealed[1:1+nonceSize], sealed[1+nonceSize:]
+	return c.aead.Open(nil, nonce, ciphertext, additionalData)
+}
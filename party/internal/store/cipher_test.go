@@ -0,0 +1,120 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func newTestCipher(t *testing.T, fill byte) *Cipher {
+	t.Helper()
+	c, err := NewCipher(bytes.Repeat([]byte{fill}, 32))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	return c
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t, 1)
+	plaintext := []byte("key share")
+
+	sealed, err := c.Seal(plaintext, []byte("key-1"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if sealed[0] != sealVersion || bytes.Contains(sealed, plaintext) {
+		t.Fatalf("sealed = %x, want a version byte followed by ciphertext", sealed)
+	}
+	opened, err := c.Open(sealed, []byte("key-1"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Fatalf("Open = %q, want %q", opened, plaintext)
+	}
+
+	// 같은 평문도 nonce가 달라 매번 다른 암호문이 됩니다.
+	again, err := c.Seal(plaintext, []byte("key-1"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if bytes.Equal(sealed, again) {
+		t.Fatal("Seal returned the same ciphertext twice")
+	}
+}
+
+func TestCipherRejectsTampering(t *testing.T) {
+	c := newTestCipher(t, 1)
+	sealed, err := c.Seal([]byte("key share"), []byte("key-1"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	flip := func(i int) []byte {
+		tampered := bytes.Clone(sealed)
+		tampered[i] ^= 1
+		return tampered
+	}
+
+	tests := []struct {
+		name   string
+		cipher *Cipher
+		sealed []byte
+		id     string
+	}{
+		{"wrong KEK", newTestCipher(t, 2), sealed, "key-1"},
+		{"other share id", c, sealed, "key-2"},
+		{"tampered ciphertext", c, flip(len(sealed) - 20), "key-1"},
+		{"tampered tag", c, flip(len(sealed) - 1), "key-1"},
+		{"tampered nonce", c, flip(1), "key-1"},
+		{"unknown version", c, flip(0), "key-1"},
+		{"truncated", c, sealed[:10], "key-1"},
+		{"empty", c, nil, "key-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cipher.Open(tt.sealed, []byte(tt.id)); err == nil {
+				t.Fatal("Open accepted the ciphertext")
+			}
+		})
+	}
+}
+
+func TestLoadKEK(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	fromEnv := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	tests := []struct {
+		name       string
+		env        *string
+		configured string
+		want       byte
+		wantErr    bool
+	}{
+		{name: "configured", configured: valid, want: 1},
+		{name: "environment wins", env: &fromEnv, configured: valid, want: 2},
+		{name: "not set", wantErr: true},
+		{name: "not base64", configured: "not base64!", wantErr: true},
+		{name: "wrong length", configured: base64.StdEncoding.EncodeToString([]byte("short")), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				t.Setenv(KEKEnv, *tt.env)
+			}
+			kek, err := LoadKEK(tt.configured)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadKEK succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKEK: %v", err)
+			}
+			if len(kek) != 32 || kek[0] != tt.want {
+				t.Fatalf("LoadKEK returned %x", kek)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"party/internal/tss"
+)
+
+// idPattern은 파일 이름으로 사용할 수 있는 저장 ID입니다. 키 ID 뒤에 ".staged" 같은 접미사 하나를 허용합니다.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[a-z]+)?$`)
+
+// FileStore는 키 조각을 저장 ID별 파일에 암호화해 저장합니다.
+// Pod가 재시작되어도 조각이 남도록 dir은 영구 볼륨에 두어야 합니다.
+type FileStore struct {
+	dir    string
+	cipher *Cipher
+}
+
+func NewFileStore(dir string, cipher *Cipher) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create share dir: %v", err)
+	}
+	return &FileStore{dir: dir, cipher: cipher}, nil
+}
+
+// Put은 키 조각을 저장합니다. 쓰는 도중에 종료되어도 기존 조각이 손상되지 않도록
+// 임시 파일에 쓰고 이름을 바꿉니다.
+func (s *FileStore) Put(id string, share *tss.KeyShare) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(s.cipher, id, share)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, sealed, 0o600); err != nil {
+		return fmt.Errorf("failed to write share %s: %v", id, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write share %s: %v", id, err)
+	}
+	return nil
+}
+
+func (s *FileStore) Get(id string) (*tss.KeyShare, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read share %s: %v", id, err)
+	}
+	return open(s.cipher, id, sealed)
+}
+
+func (s *FileStore) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete share %s: %v", id, err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if !idPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid share id: %q", id)
+	}
+	return filepath.Join(s.dir, id+".share"), nil
+}
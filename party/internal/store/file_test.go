@@ -0,0 +1,135 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestFileStore(t *testing.T) (*FileStore, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "shares")
+	s, err := NewFileStore(dir, newTestCipher(t, 1))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	return s, dir
+}
+
+func TestFileStorePutGetDelete(t *testing.T) {
+	s, dir := newTestFileStore(t)
+	share := testShare("gen-1")
+
+	if _, err := s.Get(share.KeyID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Put returned %v, want ErrNotFound", err)
+	}
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	got, err := s.Get(share.KeyID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.KeyID != share.KeyID || got.Generation != "gen-1" || len(got.Parties) != 3 {
+		t.Fatalf("Get returned %+v, want %+v", got, share)
+	}
+
+	// 파일은 소유자만 읽을 수 있고 평문 조각을 담지 않으며, 임시 파일을 남기지 않습니다.
+	path := filepath.Join(dir, share.KeyID+".share")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("share file mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if bytes.Contains(data, []byte(share.Generation)) {
+		t.Fatal("share file contains plaintext")
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("temporary file was left behind: %v", err)
+	}
+
+	// 같은 ID로 다시 저장하면 덮어씁니다.
+	if err := s.Put(share.KeyID, testShare("gen-2")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got, err := s.Get(share.KeyID); err != nil || got.Generation != "gen-2" {
+		t.Fatalf("Get after overwrite = %+v, %v; want gen-2", got, err)
+	}
+
+	if err := s.Delete(share.KeyID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(share.KeyID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete returned %v, want ErrNotFound", err)
+	}
+	// 없는 조각을 지워도 실패하지 않습니다.
+	if err := s.Delete(share.KeyID); err != nil {
+		t.Fatalf("Delete of a missing share: %v", err)
+	}
+}
+
+func TestFileStoreKeepsStagedSharesApart(t *testing.T) {
+	s, _ := newTestFileStore(t)
+	keyID := testShare("").KeyID
+	if err := s.Put(keyID, testShare("gen-1")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put(StagedID(keyID), testShare("gen-2")); err != nil {
+		t.Fatalf("Put staged: %v", err)
+	}
+
+	if got, err := s.Get(keyID); err != nil || got.Generation != "gen-1" {
+		t.Fatalf("Get = %+v, %v; want gen-1", got, err)
+	}
+	if got, err := s.Get(StagedID(keyID)); err != nil || got.Generation != "gen-2" {
+		t.Fatalf("Get staged = %+v, %v; want gen-2", got, err)
+	}
+}
+
+// 다른 KEK로 연 저장소나 다른 ID 자리로 옮긴 파일은 복호화되지 않습니다.
+func TestFileStoreRejectsForeignFiles(t *testing.T) {
+	s, dir := newTestFileStore(t)
+	share := testShare("gen-1")
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	other, err := NewFileStore(dir, newTestCipher(t, 2))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	if _, err := other.Get(share.KeyID); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get with another KEK returned %v, want a decryption error", err)
+	}
+
+	moved := "moved-key"
+	if err := os.Rename(filepath.Join(dir, share.KeyID+".share"), filepath.Join(dir, moved+".share")); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, err := s.Get(moved); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get of a moved file returned %v, want a decryption error", err)
+	}
+}
+
+func TestFileStoreRejectsInvalidIDs(t *testing.T) {
+	s, _ := newTestFileStore(t)
+	for _, id := range []string{"", "../escape", "a/b", "key.staged.tmp", "key..staged"} {
+		if err := s.Put(id, testShare("gen-1")); err == nil {
+			t.Errorf("Put accepted id %q", id)
+		}
+		if _, err := s.Get(id); err == nil {
+			t.Errorf("Get accepted id %q", id)
+		}
+		if err := s.Delete(id); err == nil {
+			t.Errorf("Delete accepted id %q", id)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"party/internal/tss"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Secret에 붙이는 라벨
+const (
+	LabelApp        = "app"
+	LabelKeyID      = "tss.bnb-chain/key-id"
+	LabelParty      = "tss.bnb-chain/party"
+	LabelPartyIndex = "tss.bnb-chain/party-index"
+	LabelGeneration = "tss.bnb-chain/generation"
+
+	secretApp     = "tss-share"
+	secretDataKey = "share"
+)
+
+// SecretStore는 파티의 키 조각을 저장 ID마다 하나의 Kubernetes Secret에 암호화해 저장합니다.
+// Secret은 Pod 이름이 아닌 파티 이름(게이트웨이가 PARTY_NAME으로 전달하는 파티 ID)으로 찾으므로,
+// Pod이 지워지고 같은 파티 ID로 다시 만들어지면 조각을 다시 읽을 수 있습니다.
+// Secret은 Pod의 소유가 아니므로 Pod가 삭제되어도 함께 삭제되지 않습니다.
+type SecretStore struct {
+	client    kubernetes.Interface
+	namespace string
+	party     string
+	cipher    *Cipher
+}
+
+func NewSecretStore(client kubernetes.Interface, namespace, party string, cipher *Cipher) *SecretStore {
+	return &SecretStore{
+		client:    client,
+		namespace: namespace,
+		party:     party,
+		cipher:    cipher,
+	}
+}
+
+// Put은 키 조각을 저장합니다. 이미 Secret이 있으면 새 조각으로 바꿉니다.
+func (s *SecretStore) Put(id string, share *tss.KeyShare) error {
+	sealed, err := seal(s.cipher, id, share)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		LabelApp:        secretApp,
+		LabelKeyID:      share.KeyID,
+		LabelParty:      s.party,
+		LabelGeneration: share.Generation,
+	}
+	if index := partyIndex(share, s.party); index >= 0 {
+		labels[LabelPartyIndex] = strconv.Itoa(index)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   s.name(id),
+			Labels: labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{secretDataKey: sealed},
+	}
+
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	_, err = secrets.Create(context.TODO(), secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		existing, getErr := secrets.Get(context.TODO(), secret.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return fmt.Errorf("failed to read share secret %s: %v", secret.Name, getErr)
+		}
+		secret.ResourceVersion = existing.ResourceVersion
+		_, err = secrets.Update(context.TODO(), secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write share secret %s: %v", secret.Name, err)
+	}
+	return nil
+}
+
+func (s *SecretStore) Get(id string) (*tss.KeyShare, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(context.TODO(), s.name(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read share secret %s: %v", id, err)
+	}
+
+	sealed, ok := secret.Data[secretDataKey]
+	if !ok {
+		return nil, fmt.Errorf("share secret %s has no %q data", secret.Name, secretDataKey)
+	}
+	return open(s.cipher, id, sealed)
+}
+
+func (s *SecretStore) Delete(id string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(context.TODO(), s.name(id), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete share secret %s: %v", id, err)
+	}
+	return nil
+}
+
+// name은 파티와 저장 ID로 Secret 이름을 만듭니다. 파티 이름(파티 ID), 키 ID(UUID)와
+// 저장 ID 접미사는 모두 DNS 이름에 쓸 수 있는 문자로 되어 있습니다.
+func (s *SecretStore) name(id string) string {
+	return fmt.Sprintf("tss-share-%s-%s", s.party, id)
+}
+
+// partyIndex는 키 생성에 참여한 파티 중 party의 순서를 반환합니다. 없으면 -1입니다.
+func partyIndex(share *tss.KeyShare, party string) int {
+	for i, name := range share.Parties {
+		if name == party {
+			return i
+		}
+	}
+	return -1
+}
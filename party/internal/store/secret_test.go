@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"party/internal/tss"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "tss"
+
+func newTestSecretStore(t *testing.T, party string) (*SecretStore, *fake.Clientset) {
+	t.Helper()
+	cipher, err := NewCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	client := fake.NewSimpleClientset()
+	return NewSecretStore(client, testNamespace, party, cipher), client
+}
+
+func testShare(generation string) *tss.KeyShare {
+	return &tss.KeyShare{
+		KeyID:      "3f1c2a9e-5b7d-4e0f-8a61-2c9d4b7e1f30",
+		Generation: generation,
+		Curve:      tss.Secp256k1,
+		Threshold:  1,
+		Parties:    []string{"tss-party-a", "tss-party-b", "tss-party-c"},
+	}
+}
+
+func TestSecretStorePutGet(t *testing.T) {
+	s, client := newTestSecretStore(t, "tss-party-b")
+	share := testShare("gen-1")
+
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, err := s.Get(share.KeyID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.KeyID != share.KeyID || got.Generation != "gen-1" || len(got.Parties) != 3 {
+		t.Fatalf("Get returned %+v, want %+v", got, share)
+	}
+
+	secret, err := client.CoreV1().Secrets(testNamespace).Get(context.Background(), s.name(share.KeyID), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("secret was not created: %v", err)
+	}
+	want := map[string]string{
+		LabelApp:        secretApp,
+		LabelKeyID:      share.KeyID,
+		LabelParty:      "tss-party-b",
+		LabelPartyIndex: "1",
+		LabelGeneration: "gen-1",
+	}
+	for label, value := range want {
+		if secret.Labels[label] != value {
+			t.Errorf("label %s = %q, want %q", label, secret.Labels[label], value)
+		}
+	}
+	if string(secret.Data[secretDataKey]) == "" {
+		t.Fatal("secret has no share data")
+	}
+}
+
+func TestSecretStoreGetNotFound(t *testing.T) {
+	s, _ := newTestSecretStore(t, "tss-party-a")
+	if _, err := s.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get of a missing share returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestSecretStoreOverwrite(t *testing.T) {
+	s, client := newTestSecretStore(t, "tss-party-a")
+	share := testShare("gen-1")
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	refreshed := testShare("gen-2")
+	if err := s.Put(refreshed.KeyID, refreshed); err != nil {
+		t.Fatalf("Put over an existing share: %v", err)
+	}
+
+	got, err := s.Get(share.KeyID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Generation != "gen-2" {
+		t.Fatalf("Get returned generation %s, want gen-2", got.Generation)
+	}
+	secret, err := client.CoreV1().Secrets(testNamespace).Get(context.Background(), s.name(share.KeyID), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get secret: %v", err)
+	}
+	if secret.Labels[LabelGeneration] != "gen-2" {
+		t.Fatalf("generation label = %q, want gen-2", secret.Labels[LabelGeneration])
+	}
+}
+
+func TestSecretStoreDelete(t *testing.T) {
+	s, _ := newTestSecretStore(t, "tss-party-a")
+	share := testShare("gen-1")
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if err := s.Delete(share.KeyID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(share.KeyID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete returned %v, want ErrNotFound", err)
+	}
+	// 이미 지운 조각을 다시 지워도 실패하지 않습니다.
+	if err := s.Delete(share.KeyID); err != nil {
+		t.Fatalf("second Delete: %v", err)
+	}
+}
+
+// 다시 만들어진 Pod은 이름이 달라도 같은 파티 ID로 조각을 읽습니다.
+func TestSecretStoreReloadByPartyID(t *testing.T) {
+	s, client := newTestSecretStore(t, "tss-party-a")
+	share := testShare("gen-1")
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	reloaded := NewSecretStore(client, testNamespace, "tss-party-a", s.cipher)
+	if _, err := reloaded.Get(share.KeyID); err != nil {
+		t.Fatalf("Get from a new store with the same party ID: %v", err)
+	}
+	other := NewSecretStore(client, testNamespace, "tss-party-b", s.cipher)
+	if _, err := other.Get(share.KeyID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get from another party returned %v, want ErrNotFound", err)
+	}
+}
+
+// 조각을 가진 파티 목록에 없는 파티는 순서 레이블을 -1로 붙이지 않고 생략합니다.
+func TestSecretStoreOmitsUnknownPartyIndex(t *testing.T) {
+	s, client := newTestSecretStore(t, "tss-party-z")
+	share := testShare("gen-1")
+	if err := s.Put(share.KeyID, share); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	secret, err := client.CoreV1().Secrets(testNamespace).Get(context.Background(), s.name(share.KeyID), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get secret: %v", err)
+	}
+	if index, ok := secret.Labels[LabelPartyIndex]; ok {
+		t.Fatalf("party index label = %q, want none", index)
+	}
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"party/internal/tss"
+)
+
+// ErrNotFound는 키 ID에 해당하는 키 조각이 없을 때 반환합니다.
+var ErrNotFound = errors.New("share not found")
+
+// Store는 파티가 보관하는 키 조각을 저장합니다.
+// id는 현재 조각이면 키 ID, 재공유로 준비 중인 조각이면 StagedID(키 ID)입니다.
+// 구현체는 키 조각을 KEK로 암호화한 상태로만 저장해야 합니다.
+type Store interface {
+	Put(id string, share *tss.KeyShare) error
+	Get(id string) (*tss.KeyShare, error)
+	Delete(id string) error
+}
+
+// StagedID는 재공유로 만들어졌지만 아직 확정되지 않은 조각의 저장 ID입니다.
+func StagedID(keyID string) string {
+	return keyID + ".staged"
+}
+
+// seal은 키 조각을 직렬화한 뒤 암호화합니다. 저장 ID를 추가 인증 데이터로 사용하므로
+// 다른 자리에 옮겨 놓은 암호문은 복호화되지 않습니다.
+func seal(c *Cipher, id string, share *tss.KeyShare) ([]byte, error) {
+	data, err := json.Marshal(share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode share %s: %v", id, err)
+	}
+	return c.Seal(data, []byte(id))
+}
+
+func open(c *Cipher, id string, sealed []byte) (*tss.KeyShare, error) {
+	data, err := c.Open(sealed, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt share %s: %v", id, err)
+	}
+	var share tss.KeyShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return nil, fmt.Errorf("failed to decode share %s: %v", id, err)
+	}
+	return &share, nil
+}
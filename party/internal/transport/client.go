@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// sendTimeout은 메시지 하나를 전달할 때 상대 파티가 준비되기를 기다리는 최대 시간입니다.
+const sendTimeout = 30 * time.Second
+
+// Client는 다른 파티의 PartyService로 라운드 메시지를 직접 보냅니다. 세션마다 Session으로 tss.Transport를 만듭니다.
+// dialOption은 다른 파티에 접속할 때의 전송 옵션(평문 또는 TLS)입니다.
+type Client struct {
+	dialOption grpc.DialOption
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func NewClient(dialOption grpc.DialOption) *Client {
+	return &Client{
+		dialOption: dialOption,
+		conns:      make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Session은 sessionID의 메시지에 게이트웨이가 발급한 token을 붙여 보내는 tss.Transport를 반환합니다.
+// 받는 파티는 이 토큰으로 발신자를 확인합니다.
+func (c *Client) Session(token string) tss.Transport {
+	return &DirectSession{client: c, token: token}
+}
+
+// DirectSession은 세션 하나의 메시지를 다른 파티에 직접 보냅니다.
+type DirectSession struct {
+	client *Client
+	token  string
+}
+
+func (s *DirectSession) Send(ctx context.Context, to tss.Peer, msg *tss.Message) error {
+	return s.client.send(metadata.AppendToOutgoingContext(ctx, TokenMetadata, s.token), to, msg)
+}
+
+func (c *Client) send(ctx context.Context, to tss.Peer, msg *tss.Message) error {
+	conn, err := c.conn(to.Address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	// 상대 파티의 gRPC 서버가 아직 시작 중일 수 있으므로 연결될 때까지 기다립니다.
+	_, err = tssv1.NewPartyServiceClient(conn).SendMessage(ctx, &tssv1.RoundMessage{
+		SessionId:   msg.SessionID,
+		From:        msg.From,
+		To:          msg.To,
+		IsBroadcast: msg.IsBroadcast,
+		Seq:         msg.Seq,
+		Payload:     msg.Payload,
+	}, grpc.WaitForReady(true))
+	return err
+}
+
+func (c *Client) conn(address string) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if conn, ok := c.conns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address, c.dialOption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to party %s: %v", address, err)
+	}
+	c.conns[address] = conn
+	return conn, nil
+}
@@ -0,0 +1,209 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// relayRetryInterval은 게이트웨이 스트림이 끊겼을 때 다시 연결하기까지의 대기 시간입니다.
+const relayRetryInterval = 3 * time.Second
+
+// 중계 스트림의 메타데이터. 게이트웨이(gateway/internal/grpc/relay.go)가 읽는 이름과 같아야 합니다.
+const (
+	partyMetadata   = "party"
+	sessionMetadata = "session"
+	// TokenMetadata는 게이트웨이가 세션 요청에 붙여 보내는 중계 토큰입니다. 파티는 이 토큰으로 세션의 중계 스트림을 엽니다.
+	TokenMetadata = "relay-token"
+)
+
+// Relay는 게이트웨이와의 양방향 스트림으로 라운드 메시지를 주고받습니다.
+// 파티끼리 직접 연결할 수 없는 클러스터에서 사용합니다.
+// 게이트웨이는 세션마다 발급한 토큰을 제시한 스트림에만 그 세션의 메시지를 보내므로, 세션마다 Session으로 스트림을 엽니다.
+type Relay struct {
+	address string
+	name    string
+	router  *Router
+	// dialOption은 게이트웨이에 접속할 때의 전송 옵션(평문 또는 TLS)입니다.
+	dialOption grpc.DialOption
+
+	once   sync.Once
+	client tssv1.RelayServiceClient
+	err    error
+}
+
+func NewRelay(address, name string, router *Router, dialOption grpc.DialOption) *Relay {
+	return &Relay{
+		address:    address,
+		name:       name,
+		router:     router,
+		dialOption: dialOption,
+	}
+}
+
+// dial은 게이트웨이 연결을 한 번만 만들고 모든 세션이 함께 사용합니다.
+func (r *Relay) dial() (tssv1.RelayServiceClient, error) {
+	r.once.Do(func() {
+		conn, err := grpc.Dial(r.address, r.dialOption)
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.client = tssv1.NewRelayServiceClient(conn)
+	})
+	return r.client, r.err
+}
+
+// Session은 게이트웨이가 발급한 token으로 sessionID의 중계 스트림을 열고, 세션의 라운드 메시지를 주고받는
+// tss.Transport를 반환합니다. 스트림이 끊기면 다시 연결하며, 세션이 끝나면 Close를 호출해야 합니다.
+func (r *Relay) Session(sessionID, token string) *RelaySession {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &RelaySession{
+		relay:     r,
+		sessionID: sessionID,
+		token:     token,
+		ctx:       ctx,
+		cancel:    cancel,
+		connected: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// RelaySession은 세션 하나의 중계 스트림입니다.
+type RelaySession struct {
+	relay     *Relay
+	sessionID string
+	token     string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	stream    tssv1.RelayService_RelayClient
+	connected chan struct{}
+
+	sendMu sync.Mutex
+}
+
+func (s *RelaySession) Send(ctx context.Context, to tss.Peer, msg *tss.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	stream, err := s.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return stream.Send(&tssv1.RoundMessage{
+		SessionId:   msg.SessionID,
+		From:        msg.From,
+		To:          msg.To,
+		IsBroadcast: msg.IsBroadcast,
+		Seq:         msg.Seq,
+		Payload:     msg.Payload,
+	})
+}
+
+// Close는 세션의 스트림을 닫습니다.
+func (s *RelaySession) Close() {
+	s.cancel()
+}
+
+// current는 연결된 스트림을 반환하며, 연결 중이면 연결될 때까지 기다립니다.
+func (s *RelaySession) current(ctx context.Context) (tssv1.RelayService_RelayClient, error) {
+	for {
+		s.mu.Lock()
+		stream, connected := s.stream, s.connected
+		s.mu.Unlock()
+		if stream != nil {
+			return stream, nil
+		}
+
+		select {
+		case <-connected:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+}
+
+func (s *RelaySession) run() {
+	client, err := s.relay.dial()
+	if err != nil {
+		log.Printf("Failed to connect to relay %s: %v", s.relay.address, err)
+		return
+	}
+
+	for {
+		err := s.relayStream(client)
+		// 게이트웨이는 세션이 닫히면 스트림을 끝내고, 닫힌 세션의 스트림은 받지 않습니다.
+		if err == io.EOF || status.Code(err) == codes.PermissionDenied {
+			return
+		}
+		if err != nil && s.ctx.Err() == nil {
+			log.Printf("Relay stream to %s for session %s closed: %v", s.relay.address, s.sessionID, err)
+		}
+		select {
+		case <-time.After(relayRetryInterval):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// relayStream은 스트림 하나를 열고, 끊길 때까지 받은 메시지를 Router로 넘깁니다.
+func (s *RelaySession) relayStream(client tssv1.RelayServiceClient) error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		partyMetadata, s.relay.name,
+		sessionMetadata, s.sessionID,
+		TokenMetadata, s.token)
+	stream, err := client.Relay(ctx, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.stream = stream
+	close(s.connected)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.stream = nil
+		s.connected = make(chan struct{})
+		s.mu.Unlock()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.relay.router.Dispatch(&tss.Message{
+			SessionID:   msg.SessionId,
+			From:        msg.From,
+			To:          msg.To,
+			IsBroadcast: msg.IsBroadcast,
+			Seq:         msg.Seq,
+			Payload:     msg.Payload,
+		})
+	}
+}
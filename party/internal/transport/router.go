@@ -0,0 +1,262 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"party/internal/tss"
+)
+
+const (
+	// maxPendingMessages는 등록되지 않은 세션에 보관할 수 있는 최대 메시지 수입니다.
+	maxPendingMessages = 1024
+	// maxPendingSessions는 메시지를 보관할 수 있는 등록되지 않은 세션의 최대 수입니다.
+	maxPendingSessions = 64
+	// pendingTTL이 지나도록 등록되지 않은 세션의 메시지는 버립니다.
+	pendingTTL = 5 * time.Minute
+)
+
+var (
+	// ErrUnknownSender는 메시지의 토큰이 발신 파티의 세션 토큰과 맞지 않을 때 반환됩니다.
+	ErrUnknownSender = errors.New("message token does not match the sender")
+	// ErrPendingFull은 등록되지 않은 세션의 메시지를 더 보관할 수 없을 때 반환됩니다.
+	ErrPendingFull = errors.New("too many pending messages")
+)
+
+// Receiver는 순서가 맞춰진 라운드 메시지를 받는 세션입니다.
+type Receiver interface {
+	Deliver(msg *tss.Message) error
+}
+
+// Router는 받은 라운드 메시지를 세션 ID로 찾아 전달합니다.
+// 발신자-수신자 쌍마다 Seq 순서대로 전달하며, 아직 등록되지 않은 세션의 메시지는
+// 등록될 때까지 보관합니다. 보관하는 세션 수와 세션마다 보관하는 메시지 수에는 한도가 있고,
+// pendingTTL이 지나도록 등록되지 않은 세션의 메시지는 버립니다.
+//
+// 다른 파티가 직접 보낸 메시지(DispatchDirect)는 세션을 등록할 때 받은 토큰 해시로 발신자를 확인합니다.
+// 등록 전에 받은 메시지는 등록할 때 확인하고, 확인하기 전에는 순서를 맞추지 않으므로
+// 위조된 메시지가 같은 Seq의 정상 메시지를 밀어내지 못합니다.
+type Router struct {
+	mu       sync.Mutex
+	sessions map[string]*inbox
+}
+
+type inbox struct {
+	receiver Receiver
+	// senders는 파티 이름별 세션 토큰의 SHA-256이며, 등록할 때 정해집니다.
+	senders map[string][]byte
+	created time.Time
+	// pending은 등록 전에 받은 메시지입니다.
+	pending []envelope
+	next    map[string]uint64
+	held    map[string]map[uint64]*tss.Message
+	queue   []*tss.Message
+	notify  chan struct{}
+	done    chan struct{}
+}
+
+// envelope는 등록 전에 받은 메시지와 발신자를 확인할 토큰입니다. relayed는 게이트웨이가 이미 발신자를 확인한 중계 메시지입니다.
+type envelope struct {
+	msg     *tss.Message
+	token   string
+	relayed bool
+}
+
+func NewRouter() *Router {
+	r := &Router{
+		sessions: make(map[string]*inbox),
+	}
+	go r.expire()
+	return r
+}
+
+// Register는 세션을 등록하고 그동안 보관된 메시지 전달을 시작합니다.
+// senders는 파티 이름별 세션 토큰의 SHA-256(PodInfo.token_hash)으로, 직접 받은 메시지의 발신자를 확인하는 데 씁니다.
+func (r *Router) Register(sessionID string, receiver Receiver, senders map[string][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ib := r.inbox(sessionID)
+	ib.receiver = receiver
+	ib.senders = senders
+	for _, env := range ib.pending {
+		if !env.relayed && !ib.authentic(env.msg, env.token) {
+			log.Printf("Session %s: dropping pending message with an invalid token from %s", sessionID, env.msg.From)
+			continue
+		}
+		ib.order(env.msg)
+	}
+	ib.pending = nil
+	go r.deliver(ib)
+	ib.signal()
+}
+
+// Unregister는 세션을 제거하고 남은 메시지를 버립니다.
+func (r *Router) Unregister(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ib, ok := r.sessions[sessionID]; ok {
+		close(ib.done)
+		delete(r.sessions, sessionID)
+	}
+}
+
+// Dispatch는 게이트웨이가 중계한 메시지를 세션의 수신 큐에 넣습니다. 게이트웨이는 중계 토큰으로 발신자를 확인한 메시지만 보냅니다.
+// 전달은 비동기로 이루어지므로 발신 파티는 수신 파티의 프로토콜 진행을 기다리지 않습니다.
+func (r *Router) Dispatch(msg *tss.Message) error {
+	return r.dispatch(envelope{msg: msg, relayed: true})
+}
+
+// DispatchDirect는 다른 파티가 직접 보낸 메시지를 세션의 수신 큐에 넣습니다. token은 발신 파티의 세션 토큰이며,
+// 등록된 세션에서 발신자와 맞지 않으면 ErrUnknownSender를 반환합니다.
+func (r *Router) DispatchDirect(msg *tss.Message, token string) error {
+	return r.dispatch(envelope{msg: msg, token: token})
+}
+
+func (r *Router) dispatch(env envelope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg := env.msg
+	ib, ok := r.sessions[msg.SessionID]
+	if !ok {
+		if r.pendingSessions() >= maxPendingSessions {
+			log.Printf("Session %s: too many pending sessions, dropping message from %s", msg.SessionID, msg.From)
+			return ErrPendingFull
+		}
+		ib = r.inbox(msg.SessionID)
+	}
+
+	if ib.receiver == nil {
+		if len(ib.pending) >= maxPendingMessages {
+			log.Printf("Session %s: pending buffer is full, dropping message from %s", msg.SessionID, msg.From)
+			return ErrPendingFull
+		}
+		ib.pending = append(ib.pending, env)
+		return nil
+	}
+
+	if !env.relayed && !ib.authentic(msg, env.token) {
+		log.Printf("Session %s: rejected message with an invalid token from %s", msg.SessionID, msg.From)
+		return ErrUnknownSender
+	}
+	ib.order(msg)
+	ib.signal()
+	return nil
+}
+
+func (r *Router) inbox(sessionID string) *inbox {
+	ib, ok := r.sessions[sessionID]
+	if !ok {
+		ib = &inbox{
+			created: time.Now(),
+			next:    make(map[string]uint64),
+			held:    make(map[string]map[uint64]*tss.Message),
+			notify:  make(chan struct{}, 1),
+			done:    make(chan struct{}),
+		}
+		r.sessions[sessionID] = ib
+	}
+	return ib
+}
+
+// pendingSessions는 메시지를 보관 중인 등록되지 않은 세션 수입니다.
+func (r *Router) pendingSessions() int {
+	n := 0
+	for _, ib := range r.sessions {
+		if ib.receiver == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Router) deliver(ib *inbox) {
+	for {
+		select {
+		case <-ib.notify:
+		case <-ib.done:
+			return
+		}
+
+		r.mu.Lock()
+		queue := ib.queue
+		ib.queue = nil
+		r.mu.Unlock()
+
+		for _, msg := range queue {
+			if err := ib.receiver.Deliver(msg); err != nil {
+				log.Printf("Session %s: failed to deliver message from %s: %v", msg.SessionID, msg.From, err)
+			}
+		}
+	}
+}
+
+// expire는 오래도록 등록되지 않은 세션의 보관 메시지를 정리합니다.
+func (r *Router) expire() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		r.expirePending(now)
+	}
+}
+
+// expirePending은 now에 pendingTTL이 지난 등록되지 않은 세션을 제거합니다.
+func (r *Router) expirePending(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for sessionID, ib := range r.sessions {
+		if ib.receiver == nil && now.Sub(ib.created) > pendingTTL {
+			log.Printf("Session %s: dropping %d pending messages", sessionID, len(ib.pending))
+			close(ib.done)
+			delete(r.sessions, sessionID)
+		}
+	}
+}
+
+// authentic은 token이 메시지 발신 파티의 세션 토큰인지 확인합니다.
+func (ib *inbox) authentic(msg *tss.Message, token string) bool {
+	want, ok := ib.senders[tss.PartyName(msg.From)]
+	if !ok || token == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(token))
+	return subtle.ConstantTimeCompare(sum[:], want) == 1
+}
+
+// order는 메시지를 발신자-수신자 쌍의 Seq 순서대로 수신 큐에 넣습니다. 순서가 빠진 메시지는 앞의 메시지가 올 때까지 보관합니다.
+func (ib *inbox) order(msg *tss.Message) {
+	stream := msg.From + "/" + msg.To
+	if msg.Seq < ib.next[stream] {
+		// 이미 전달한 메시지의 재전송입니다.
+		return
+	}
+	if ib.held[stream] == nil {
+		ib.held[stream] = make(map[uint64]*tss.Message)
+	}
+	ib.held[stream][msg.Seq] = msg
+
+	for {
+		next, ok := ib.held[stream][ib.next[stream]]
+		if !ok {
+			break
+		}
+		delete(ib.held[stream], ib.next[stream])
+		ib.queue = append(ib.queue, next)
+		ib.next[stream]++
+	}
+}
+
+func (ib *inbox) signal() {
+	select {
+	case ib.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,240 @@
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// recorder는 전달받은 메시지를 채널로 넘기는 Receiver입니다.
+type recorder chan *tss.Message
+
+func (r recorder) Deliver(msg *tss.Message) error {
+	r <- msg
+	return nil
+}
+
+// next는 다음으로 전달된 메시지의 "From:Seq"를 반환합니다.
+func (r recorder) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case msg := <-r:
+		return fmt.Sprintf("%s:%d", msg.From, msg.Seq)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message was delivered")
+		return ""
+	}
+}
+
+// none은 더 전달된 메시지가 없는지 확인합니다.
+func (r recorder) none(t *testing.T) {
+	t.Helper()
+	select {
+	case msg := <-r:
+		t.Fatalf("unexpected message %s:%d", msg.From, msg.Seq)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// testSenders는 파티 a, b의 세션 토큰이 "token-a", "token-b"인 세션의 토큰 해시입니다.
+func testSenders() map[string][]byte {
+	senders := make(map[string][]byte)
+	for _, name := range []string{"a", "b"} {
+		sum := sha256.Sum256([]byte("token-" + name))
+		senders[name] = sum[:]
+	}
+	return senders
+}
+
+func pending(r *Router) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pendingSessions()
+}
+
+func message(from string, seq uint64) *tss.Message {
+	return &tss.Message{SessionID: "session-1", From: from, To: "self", Seq: seq}
+}
+
+func TestRouterOrdersBySeq(t *testing.T) {
+	r := NewRouter()
+	received := make(recorder, 16)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+
+	// 발신자마다 Seq 순서대로 전달하며, 다른 발신자의 메시지는 기다리지 않습니다.
+	for _, msg := range []*tss.Message{message("a", 1), message("b", 0), message("a", 2), message("a", 0)} {
+		if err := r.DispatchDirect(msg, "token-"+msg.From); err != nil {
+			t.Fatalf("DispatchDirect %s:%d: %v", msg.From, msg.Seq, err)
+		}
+	}
+	for _, want := range []string{"b:0", "a:0", "a:1", "a:2"} {
+		if got := received.next(t); got != want {
+			t.Fatalf("delivered %s, want %s", got, want)
+		}
+	}
+
+	// 이미 전달한 메시지의 재전송은 버립니다.
+	if err := r.DispatchDirect(message("a", 1), "token-a"); err != nil {
+		t.Fatalf("DispatchDirect: %v", err)
+	}
+	received.none(t)
+}
+
+func TestRouterRejectsUnknownSender(t *testing.T) {
+	r := NewRouter()
+	received := make(recorder, 16)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+
+	tests := []struct {
+		name  string
+		msg   *tss.Message
+		token string
+	}{
+		{"token of another party", message("a", 0), "token-b"},
+		{"no token", message("a", 0), ""},
+		{"party outside the session", message("c", 0), "token-c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.DispatchDirect(tt.msg, tt.token); !errors.Is(err, ErrUnknownSender) {
+				t.Fatalf("DispatchDirect returned %v, want ErrUnknownSender", err)
+			}
+		})
+	}
+	received.none(t)
+
+	// 위조된 메시지는 Seq를 차지하지 않으므로 같은 Seq의 정상 메시지가 전달됩니다.
+	if err := r.DispatchDirect(message("a", 0), "token-a"); err != nil {
+		t.Fatalf("DispatchDirect: %v", err)
+	}
+	if got := received.next(t); got != "a:0" {
+		t.Fatalf("delivered %s, want a:0", got)
+	}
+}
+
+// 등록 전에 받은 메시지는 등록할 때 발신자를 확인한 뒤 순서대로 전달합니다.
+func TestRouterBuffersUntilRegister(t *testing.T) {
+	r := NewRouter()
+	for _, d := range []struct {
+		msg   *tss.Message
+		token string
+	}{
+		{message("a", 0), "token-b"},
+		{message("a", 1), "token-a"},
+		{message("a", 0), "token-a"},
+	} {
+		if err := r.DispatchDirect(d.msg, d.token); err != nil {
+			t.Fatalf("DispatchDirect before Register: %v", err)
+		}
+	}
+	// 게이트웨이가 중계한 메시지는 토큰 없이 받습니다.
+	if err := r.Dispatch(message("b", 0)); err != nil {
+		t.Fatalf("Dispatch before Register: %v", err)
+	}
+
+	received := make(recorder, 16)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+	for _, want := range []string{"a:0", "a:1", "b:0"} {
+		if got := received.next(t); got != want {
+			t.Fatalf("delivered %s, want %s", got, want)
+		}
+	}
+	received.none(t)
+}
+
+func TestRouterLimitsPendingMessages(t *testing.T) {
+	r := NewRouter()
+	for i := 0; i < maxPendingMessages; i++ {
+		if err := r.DispatchDirect(message("a", uint64(i)), "token-a"); err != nil {
+			t.Fatalf("DispatchDirect %d: %v", i, err)
+		}
+	}
+	if err := r.DispatchDirect(message("a", maxPendingMessages), "token-a"); !errors.Is(err, ErrPendingFull) {
+		t.Fatalf("DispatchDirect over the limit returned %v, want ErrPendingFull", err)
+	}
+
+	// 등록되지 않은 세션 수에도 한도가 있습니다.
+	for i := 1; i < maxPendingSessions; i++ {
+		msg := &tss.Message{SessionID: fmt.Sprintf("session-%d", i+1), From: "a"}
+		if err := r.DispatchDirect(msg, "token-a"); err != nil {
+			t.Fatalf("DispatchDirect to session %d: %v", i+1, err)
+		}
+	}
+	if err := r.DispatchDirect(&tss.Message{SessionID: "session-overflow", From: "a"}, "token-a"); !errors.Is(err, ErrPendingFull) {
+		t.Fatalf("DispatchDirect to one more session returned %v, want ErrPendingFull", err)
+	}
+
+	// 등록된 세션은 한도에 들지 않습니다.
+	received := make(recorder, maxPendingMessages)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+	if err := r.DispatchDirect(&tss.Message{SessionID: "session-overflow", From: "a"}, "token-a"); err != nil {
+		t.Fatalf("DispatchDirect after a pending session was registered: %v", err)
+	}
+}
+
+func TestRouterExpiresPendingSessions(t *testing.T) {
+	r := NewRouter()
+	if err := r.DispatchDirect(message("a", 0), "token-a"); err != nil {
+		t.Fatalf("DispatchDirect: %v", err)
+	}
+
+	r.expirePending(time.Now().Add(pendingTTL / 2))
+	if pending(r) != 1 {
+		t.Fatal("pending session expired before pendingTTL")
+	}
+	r.expirePending(time.Now().Add(2 * pendingTTL))
+	if pending(r) != 0 {
+		t.Fatal("pending session was not expired after pendingTTL")
+	}
+
+	// 만료된 메시지는 세션을 등록해도 전달되지 않습니다.
+	received := make(recorder, 1)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+	received.none(t)
+
+	// 등록된 세션은 만료되지 않습니다.
+	r.expirePending(time.Now().Add(2 * pendingTTL))
+	if err := r.DispatchDirect(message("a", 0), "token-a"); err != nil {
+		t.Fatalf("DispatchDirect: %v", err)
+	}
+	if got := received.next(t); got != "a:0" {
+		t.Fatalf("delivered %s, want a:0", got)
+	}
+}
+
+func TestServerAuthenticatesSender(t *testing.T) {
+	r := NewRouter()
+	received := make(recorder, 1)
+	r.Register("session-1", received, testSenders())
+	defer r.Unregister("session-1")
+	server := NewServer(r)
+
+	req := &tssv1.RoundMessage{SessionId: "session-1", From: "a", To: "self"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TokenMetadata, "token-b"))
+	if _, err := server.SendMessage(ctx, req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("SendMessage with the token of another party returned %v, want PermissionDenied", err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(TokenMetadata, "token-a"))
+	if _, err := server.SendMessage(ctx, req); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if got := received.next(t); got != "a:0" {
+		t.Fatalf("delivered %s, want a:0", got)
+	}
+}
@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"errors"
+
+	"party/internal/tss"
+	"proto/tss/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server는 다른 파티가 보낸 라운드 메시지를 받아 Router로 넘깁니다.
+// 보내는 파티는 TokenMetadata에 게이트웨이가 발급한 자신의 세션 토큰을 붙이며, Router가 토큰으로 From을 확인합니다.
+type Server struct {
+	tssv1.UnimplementedPartyServiceServer
+	router *Router
+}
+
+func NewServer(router *Router) *Server {
+	return &Server{router: router}
+}
+
+func (s *Server) SendMessage(ctx context.Context, req *tssv1.RoundMessage) (*tssv1.SendMessageResponse, error) {
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TokenMetadata); len(values) > 0 {
+			token = values[0]
+		}
+	}
+
+	err := s.router.DispatchDirect(&tss.Message{
+		SessionID:   req.SessionId,
+		From:        req.From,
+		To:          req.To,
+		IsBroadcast: req.IsBroadcast,
+		Seq:         req.Seq,
+		Payload:     req.Payload,
+	}, token)
+	switch {
+	case errors.Is(err, ErrUnknownSender):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrPendingFull):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &tssv1.SendMessageResponse{}, nil
+}
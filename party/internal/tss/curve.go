@@ -0,0 +1,53 @@
+package tss
+
+import (
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Curve는 키가 사용하는 곡선입니다.
+type Curve string
+
+const (
+	// Secp256k1은 tss-lib ECDSA 프로토콜을 사용합니다.
+	Secp256k1 Curve = "secp256k1"
+	// Ed25519는 tss-lib EdDSA 프로토콜을 사용합니다.
+	Ed25519 Curve = "ed25519"
+)
+
+// EncodePublicKey는 곡선에 맞는 형식으로 공개키를 인코딩합니다.
+// secp256k1은 33바이트 압축 형식, ed25519는 RFC 8032의 32바이트 형식입니다.
+func EncodePublicKey(curve Curve, pub *crypto.ECPoint) ([]byte, error) {
+	switch curve {
+	case Secp256k1:
+		return compressedPublicKey(pub), nil
+	case Ed25519:
+		return ed25519PublicKey(pub), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", curve)
+	}
+}
+
+func compressedPublicKey(pub *crypto.ECPoint) []byte {
+	out := make([]byte, 33)
+	out[0] = 0x02
+	if pub.Y().Bit(0) == 1 {
+		out[0] = 0x03
+	}
+	pub.X().FillBytes(out[1:])
+	return out
+}
+
+// ed25519PublicKey는 y 좌표를 리틀 엔디언으로 쓰고 마지막 비트에 x의 부호를 넣습니다.
+func ed25519PublicKey(pub *crypto.ECPoint) []byte {
+	out := make([]byte, 32)
+	pub.Y().FillBytes(out)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	if pub.X().Bit(0) == 1 {
+		out[31] |= 0x80
+	}
+	return out
+}
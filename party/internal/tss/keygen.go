@@ -0,0 +1,52 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	tsslib "github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Keygen은 곡선에 맞는 tss-lib 키 생성 프로토콜을 실행하고 로컬 파티의 키 조각을 반환합니다.
+// threshold는 tss-lib의 임계값 t이며, 서명에는 t+1개의 파티가 필요합니다.
+// preParams는 미리 만들어 둔 ECDSA Paillier 사전 파라미터이며, nil이면 tss-lib가 키 생성 중에 만듭니다.
+// 반환된 키 조각의 KeyID는 호출한 쪽에서 채웁니다.
+func (s *Session) Keygen(ctx context.Context, curve Curve, threshold int, preParams *ecdsakeygen.LocalPreParams) (*KeyShare, error) {
+	share := &KeyShare{
+		Curve:     curve,
+		Threshold: threshold,
+		Parties:   s.names(),
+	}
+
+	switch curve {
+	case Secp256k1:
+		params := tsslib.NewParameters(tsslib.S256(), tsslib.NewPeerContext(s.ids), s.self, len(s.ids), threshold)
+		end := make(chan *ecdsakeygen.LocalPartySaveData, 1)
+		var party tsslib.Party
+		if preParams != nil {
+			party = ecdsakeygen.NewLocalParty(params, s.out, end, *preParams)
+		} else {
+			party = ecdsakeygen.NewLocalParty(params, s.out, end)
+		}
+		data, err := run(ctx, s, party, end)
+		if err != nil {
+			return nil, err
+		}
+		share.ECDSA = data
+	case Ed25519:
+		params := tsslib.NewParameters(tsslib.Edwards(), tsslib.NewPeerContext(s.ids), s.self, len(s.ids), threshold)
+		end := make(chan *eddsakeygen.LocalPartySaveData, 1)
+		party := eddsakeygen.NewLocalParty(params, s.out, end)
+		data, err := run(ctx, s, party, end)
+		if err != nil {
+			return nil, err
+		}
+		share.EdDSA = data
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", curve)
+	}
+
+	return share, nil
+}
@@ -0,0 +1,55 @@
+package tss
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+
+	tsslib "github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Peer는 프로토콜에 참여하는 파티와 해당 파티의 gRPC 주소입니다.
+// Key는 tss-lib PartyID 키이며, 비어 있으면 이름으로부터 만듭니다.
+type Peer struct {
+	Name    string
+	Address string
+	Key     *big.Int
+}
+
+// newCommitteeSuffix는 재공유에서 새 위원회 역할의 PartyID에 붙는 접미사입니다.
+// 한 파티가 두 위원회에 모두 속하면 "<이름>"과 "<이름>#new" 두 PartyID로 참여합니다.
+const newCommitteeSuffix = "#new"
+
+// PartyName은 메시지의 From, To와 같은 PartyID에서 파티 이름을 꺼냅니다.
+func PartyName(id string) string {
+	return strings.TrimSuffix(id, newCommitteeSuffix)
+}
+
+// partyKey는 파티 이름으로부터 tss-lib PartyID 키를 만듭니다.
+// 모든 파티가 같은 값을 계산할 수 있도록 이름의 해시를 사용합니다.
+func partyKey(name string) *big.Int {
+	sum := sha256.Sum256([]byte(name))
+	return new(big.Int).SetBytes(sum[:])
+}
+
+// sortedPartyIDs는 참여 파티 목록으로 정렬된 PartyID 목록을 만듭니다.
+func sortedPartyIDs(peers []Peer) (tsslib.SortedPartyIDs, error) {
+	seen := make(map[string]bool, len(peers))
+	unsorted := make(tsslib.UnSortedPartyIDs, 0, len(peers))
+	for _, peer := range peers {
+		if peer.Name == "" {
+			return nil, fmt.Errorf("party name is empty for %s", peer.Address)
+		}
+		if seen[peer.Name] {
+			return nil, fmt.Errorf("duplicate party name: %s", peer.Name)
+		}
+		seen[peer.Name] = true
+		key := peer.Key
+		if key == nil {
+			key = partyKey(peer.Name)
+		}
+		unsorted = append(unsorted, tsslib.NewPartyID(peer.Name, peer.Name, key))
+	}
+	return tsslib.SortPartyIDs(unsorted), nil
+}
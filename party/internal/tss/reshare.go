@@ -0,0 +1,201 @@
+package tss
+
+import (
+	"context"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+	"sync"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsaresharing "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaresharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	tsslib "github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Reshare는 공개키를 유지한 채 키 조각을 새 위원회로 옮기는 재공유 세션입니다.
+// 로컬 파티는 기존 위원회, 새 위원회 또는 둘 모두에 속할 수 있으며 역할마다 Session을 하나씩 실행합니다.
+// 새 위원회 역할의 PartyID 이름에는 newCommitteeSuffix가 붙습니다.
+type Reshare struct {
+	ID string
+
+	oldIDs tsslib.SortedPartyIDs
+	newIDs tsslib.SortedPartyIDs
+	old    *Session
+	new    *Session
+}
+
+// NewReshare는 기존 위원회 oldPeers와 새 위원회 newPeers 사이의 재공유 세션을 만듭니다.
+// 두 위원회의 Peer 이름은 파티 이름이며, Key는 각 위원회에서 사용할 PartyID 키입니다.
+func NewReshare(id, self string, oldPeers, newPeers []Peer, transport Transport) (*Reshare, error) {
+	oldIDs, err := sortedPartyIDs(oldPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	qualified := make([]Peer, len(newPeers))
+	for i, peer := range newPeers {
+		qualified[i] = peer
+		qualified[i].Name = peer.Name + newCommitteeSuffix
+	}
+	newIDs, err := sortedPartyIDs(qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	known := append(append(tsslib.SortedPartyIDs{}, oldIDs...), newIDs...)
+	peers := append(append([]Peer{}, oldPeers...), qualified...)
+
+	r := &Reshare{ID: id, oldIDs: oldIDs, newIDs: newIDs}
+	if hasID(oldIDs, self) {
+		if r.old, err = newSession(id, self, oldIDs, known, peers, transport); err != nil {
+			return nil, err
+		}
+	}
+	if hasID(newIDs, self+newCommitteeSuffix) {
+		if r.new, err = newSession(id, self+newCommitteeSuffix, newIDs, known, peers, transport); err != nil {
+			return nil, err
+		}
+	}
+	if r.old == nil && r.new == nil {
+		return nil, fmt.Errorf("party %s is not a participant", self)
+	}
+	return r, nil
+}
+
+// InOldCommittee는 로컬 파티가 기존 위원회에 속하는지 확인합니다.
+func (r *Reshare) InOldCommittee() bool {
+	return r.old != nil
+}
+
+// InNewCommittee는 로컬 파티가 새 위원회에 속하는지 확인합니다.
+func (r *Reshare) InNewCommittee() bool {
+	return r.new != nil
+}
+
+// Deliver는 메시지를 수신 대상 역할의 세션에 전달합니다.
+func (r *Reshare) Deliver(msg *Message) error {
+	switch {
+	case r.new != nil && msg.To == r.new.self.Id:
+		return r.new.Deliver(msg)
+	case r.old != nil && msg.To == r.old.self.Id:
+		return r.old.Deliver(msg)
+	default:
+		return fmt.Errorf("message for %s is not addressed to this party", msg.To)
+	}
+}
+
+// Run은 재공유 프로토콜을 실행합니다. share는 기존 위원회 역할에서 사용할 키 조각이며
+// 새 위원회에만 속한 파티는 nil을 넘깁니다. preParams는 새 위원회의 ECDSA 역할에 필요합니다.
+// 새 위원회에 속하면 새 키 조각을 반환하고, 기존 위원회에만 속하면 nil을 반환합니다.
+// 반환된 키 조각의 KeyID는 호출한 쪽에서 채웁니다.
+func (r *Reshare) Run(ctx context.Context, share *KeyShare, curve Curve, oldThreshold, newThreshold int, preParams *ecdsakeygen.LocalPreParams) (*KeyShare, error) {
+	if r.old != nil && share == nil {
+		return nil, fmt.Errorf("party %s has no share to reshare", r.old.self.Id)
+	}
+	if r.new != nil && curve == Secp256k1 && preParams == nil {
+		return nil, fmt.Errorf("pre-params are required to join the new committee")
+	}
+
+	var ec elliptic.Curve
+	switch curve {
+	case Secp256k1:
+		ec = tsslib.S256()
+	case Ed25519:
+		ec = tsslib.Edwards()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", curve)
+	}
+	params := func(self *tsslib.PartyID) *tsslib.ReSharingParameters {
+		return tsslib.NewReSharingParameters(ec, tsslib.NewPeerContext(r.oldIDs), tsslib.NewPeerContext(r.newIDs),
+			self, len(r.oldIDs), oldThreshold, len(r.newIDs), newThreshold)
+	}
+
+	// 한 역할이 실패하면 다른 역할도 더 기다리지 않도록 함께 취소합니다.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, 2)
+	fail := func(err error) {
+		errCh <- err
+		cancel()
+	}
+
+	if r.old != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			switch curve {
+			case Secp256k1:
+				end := make(chan *ecdsakeygen.LocalPartySaveData, 1)
+				data := ecdsakeygen.BuildLocalSaveDataSubset(*share.ECDSA, r.oldIDs)
+				_, err = run(ctx, r.old, ecdsaresharing.NewLocalParty(params(r.old.self), data, r.old.out, end), end)
+			case Ed25519:
+				end := make(chan *eddsakeygen.LocalPartySaveData, 1)
+				data := eddsakeygen.BuildLocalSaveDataSubset(*share.EdDSA, r.oldIDs)
+				_, err = run(ctx, r.old, eddsaresharing.NewLocalParty(params(r.old.self), data, r.old.out, end), end)
+			}
+			if err != nil {
+				fail(fmt.Errorf("old committee: %v", err))
+			}
+		}()
+	}
+
+	var result *KeyShare
+	if r.new != nil {
+		result = r.newShare(curve, newThreshold)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			switch curve {
+			case Secp256k1:
+				end := make(chan *ecdsakeygen.LocalPartySaveData, 1)
+				save := ecdsakeygen.NewLocalPartySaveData(len(r.newIDs))
+				save.LocalPreParams = *preParams
+				result.ECDSA, err = run(ctx, r.new, ecdsaresharing.NewLocalParty(params(r.new.self), save, r.new.out, end), end)
+			case Ed25519:
+				end := make(chan *eddsakeygen.LocalPartySaveData, 1)
+				save := eddsakeygen.NewLocalPartySaveData(len(r.newIDs))
+				result.EdDSA, err = run(ctx, r.new, eddsaresharing.NewLocalParty(params(r.new.self), save, r.new.out, end), end)
+			}
+			if err != nil {
+				fail(fmt.Errorf("new committee: %v", err))
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newShare는 새 위원회의 파티 이름과 PartyID 키를 담은 빈 키 조각을 만듭니다.
+func (r *Reshare) newShare(curve Curve, threshold int) *KeyShare {
+	share := &KeyShare{
+		Curve:     curve,
+		Threshold: threshold,
+		Parties:   make([]string, len(r.newIDs)),
+		PartyKeys: make([]*big.Int, len(r.newIDs)),
+	}
+	for i, id := range r.newIDs {
+		share.Parties[i] = PartyName(id.Id)
+		share.PartyKeys[i] = id.KeyInt()
+	}
+	return share
+}
+
+func hasID(ids tsslib.SortedPartyIDs, name string) bool {
+	for _, id := range ids {
+		if id.Id == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"sync"
+
+	tsslib "github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// roundPattern은 tss-lib 메시지 타입 이름(예: KGRound2Message1)에서 라운드 번호를 찾습니다.
+var roundPattern = regexp.MustCompile(`Round(\d+)`)
+
+// Session은 하나의 프로토콜 실행에 참여하는 로컬 파티의 상태입니다.
+// 로컬 파티가 만든 메시지는 Transport로 내보내고, 다른 파티의 메시지는 Deliver로 받습니다.
+type Session struct {
+	ID string
+
+	// OnRound가 설정되어 있으면 로컬 파티가 새 라운드의 메시지를 처음 보낼 때 호출됩니다.
+	// 메시지 전송을 막지 않도록 별도의 고루틴에서 호출됩니다.
+	OnRound func(round int)
+	round   int
+
+	self      *tsslib.PartyID
+	ids       tsslib.SortedPartyIDs
+	known     map[string]*tsslib.PartyID
+	peers     map[string]Peer
+	transport Transport
+	seq       map[string]uint64
+
+	mu      sync.Mutex
+	party   tsslib.Party
+	started bool
+	pending []*Message
+	out     chan tsslib.Message
+	errCh   chan error
+}
+
+// NewSession은 참여 파티 목록 중 self 이름을 가진 파티로 세션을 만듭니다.
+func NewSession(id, self string, peers []Peer, transport Transport) (*Session, error) {
+	ids, err := sortedPartyIDs(peers)
+	if err != nil {
+		return nil, err
+	}
+	return newSession(id, self, ids, ids, peers, transport)
+}
+
+// newSession은 ids 위원회에 속한 self 파티의 세션을 만듭니다.
+// 재공유에서는 다른 위원회의 파티와도 메시지를 주고받으므로 known과 peers에 두 위원회가 모두 들어갑니다.
+func newSession(id, self string, ids, known tsslib.SortedPartyIDs, peers []Peer, transport Transport) (*Session, error) {
+	var selfID *tsslib.PartyID
+	for _, id := range ids {
+		if id.Id == self {
+			selfID = id
+			break
+		}
+	}
+	if selfID == nil {
+		return nil, fmt.Errorf("party %s is not a participant", self)
+	}
+
+	knownMap := make(map[string]*tsslib.PartyID, len(known))
+	for _, id := range known {
+		knownMap[id.Id] = id
+	}
+	peerMap := make(map[string]Peer, len(peers))
+	for _, peer := range peers {
+		peerMap[peer.Name] = peer
+	}
+
+	return &Session{
+		ID:        id,
+		self:      selfID,
+		ids:       ids,
+		known:     knownMap,
+		peers:     peerMap,
+		transport: transport,
+		seq:       make(map[string]uint64, len(peers)),
+		out:       make(chan tsslib.Message, len(peers)),
+		errCh:     make(chan error, 1),
+	}, nil
+}
+
+// Deliver는 다른 파티로부터 받은 메시지를 로컬 파티에 전달합니다.
+// 로컬 파티가 아직 시작되지 않았다면 시작될 때까지 메시지를 보관합니다.
+// tss-lib는 시작 전에 받은 메시지를 버리기 때문입니다.
+func (s *Session) Deliver(msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		s.pending = append(s.pending, msg)
+		return nil
+	}
+	return s.update(msg)
+}
+
+func (s *Session) update(msg *Message) error {
+	from := s.partyID(msg.From)
+	if from == nil {
+		return fmt.Errorf("unknown sender: %s", msg.From)
+	}
+
+	if _, err := s.party.UpdateFromBytes(msg.Payload, from, msg.IsBroadcast); err != nil {
+		s.fail(err)
+		return err
+	}
+	return nil
+}
+
+// start는 로컬 파티를 시작하고 보관 중이던 메시지를 순서대로 전달합니다.
+func (s *Session) start(party tsslib.Party) {
+	if err := party.Start(); err != nil {
+		s.fail(err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.party = party
+	s.started = true
+	for _, msg := range s.pending {
+		if err := s.update(msg); err != nil {
+			log.Printf("Session %s: dropped message from %s: %v", s.ID, msg.From, err)
+		}
+	}
+	s.pending = nil
+}
+
+// names는 세션에 참여한 파티 이름을 정렬된 순서로 반환합니다.
+func (s *Session) names() []string {
+	names := make([]string, len(s.ids))
+	for i, id := range s.ids {
+		names[i] = id.Id
+	}
+	return names
+}
+
+func (s *Session) partyID(name string) *tsslib.PartyID {
+	return s.known[name]
+}
+
+func (s *Session) fail(err error) {
+	select {
+	case s.errCh <- err:
+	default:
+	}
+}
+
+// send는 로컬 파티가 만든 메시지를 수신 대상 파티들에게 보냅니다.
+// 수신 대상이 없는 메시지는 자신을 제외한 모든 파티에게 보냅니다.
+func (s *Session) send(ctx context.Context, msg tsslib.Message) error {
+	payload, routing, err := msg.WireBytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", msg.Type(), err)
+	}
+
+	s.enterRound(msg.Type())
+
+	to := routing.To
+	if to == nil {
+		to = s.ids
+	}
+
+	for _, id := range to {
+		if id.Id == s.self.Id {
+			continue
+		}
+		peer, ok := s.peers[id.Id]
+		if !ok {
+			return fmt.Errorf("no address for party %s", id.Id)
+		}
+		err := s.transport.Send(ctx, peer, &Message{
+			SessionID:   s.ID,
+			From:        s.self.Id,
+			To:          id.Id,
+			IsBroadcast: routing.IsBroadcast,
+			Seq:         s.seq[id.Id],
+			Payload:     payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send %s to %s: %v", msg.Type(), id.Id, err)
+		}
+		s.seq[id.Id]++
+	}
+	return nil
+}
+
+// enterRound는 보내는 메시지의 라운드가 바뀌었으면 OnRound로 알립니다.
+func (s *Session) enterRound(msgType string) {
+	m := roundPattern.FindStringSubmatch(msgType)
+	if m == nil {
+		return
+	}
+	round, err := strconv.Atoi(m[1])
+	if err != nil || round <= s.round {
+		return
+	}
+	s.round = round
+	if s.OnRound != nil {
+		go s.OnRound(round)
+	}
+}
+
+// run은 로컬 파티를 시작하고 결과가 나올 때까지 메시지를 중계합니다.
+func run[T any](ctx context.Context, s *Session, party tsslib.Party, end <-chan T) (T, error) {
+	var zero T
+
+	go s.start(party)
+
+	for {
+		select {
+		case msg := <-s.out:
+			if err := s.send(ctx, msg); err != nil {
+				return zero, err
+			}
+		case result := <-end:
+			// 마지막 라운드 메시지가 남아 있다면 다른 파티도 끝낼 수 있도록 먼저 보냅니다.
+			if err := s.flush(ctx); err != nil {
+				return zero, err
+			}
+			return result, nil
+		case err := <-s.errCh:
+			return zero, err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+func (s *Session) flush(ctx context.Context) error {
+	for {
+		select {
+		case msg := <-s.out:
+			if err := s.send(ctx, msg); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tss
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+)
+
+// KeyShare는 키 하나에 대해 로컬 파티가 보관하는 키 조각입니다.
+// Parties는 조각을 가진 파티 이름 목록이며, 곡선에 따라 ECDSA나 EdDSA 중 하나만 채워집니다.
+// PartyKeys는 Parties와 같은 순서의 PartyID 키입니다. 키 생성으로 만든 조각은 비어 있으며
+// 이때는 이름으로부터 키를 만듭니다. 재공유로 받은 조각은 재공유 때의 키를 보관합니다.
+// Generation은 조각을 만든 키 생성 또는 재공유 세션의 ID입니다.
+type KeyShare struct {
+	KeyID      string
+	Generation string
+	Curve      Curve
+	Threshold  int
+	Parties    []string
+	PartyKeys  []*big.Int `json:",omitempty"`
+	ECDSA      *ecdsakeygen.LocalPartySaveData
+	EdDSA      *eddsakeygen.LocalPartySaveData
+}
+
+// HasParty는 name 파티가 이 키의 조각을 가지고 있는지 확인합니다.
+func (k *KeyShare) HasParty(name string) bool {
+	for _, party := range k.Parties {
+		if party == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignKeys는 peers에 이 키 조각을 만들 때 사용한 PartyID 키를 채워 반환합니다.
+// 조각을 가진 파티들로 프로토콜을 실행하려면 같은 키를 사용해야 합니다.
+func (k *KeyShare) AssignKeys(peers []Peer) []Peer {
+	out := make([]Peer, len(peers))
+	for i, peer := range peers {
+		out[i] = peer
+		out[i].Key = k.keyOf(peer.Name)
+	}
+	return out
+}
+
+func (k *KeyShare) keyOf(name string) *big.Int {
+	for i, party := range k.Parties {
+		if party == name && i < len(k.PartyKeys) {
+			return k.PartyKeys[i]
+		}
+	}
+	return partyKey(name)
+}
+
+// PublicKey는 곡선에 맞게 인코딩된 공동 공개키를 반환합니다.
+func (k *KeyShare) PublicKey() ([]byte, error) {
+	var pub *crypto.ECPoint
+	switch {
+	case k.ECDSA != nil:
+		pub = k.ECDSA.ECDSAPub
+	case k.EdDSA != nil:
+		pub = k.EdDSA.EDDSAPub
+	default:
+		return nil, fmt.Errorf("key %s has no share data", k.KeyID)
+	}
+	return EncodePublicKey(k.Curve, pub)
+}
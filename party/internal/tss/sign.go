@@ -0,0 +1,47 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsasigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	tsslib "github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Sign은 세션의 파티들과 키의 곡선에 맞는 서명 프로토콜을 실행합니다.
+// 세션에는 키 생성에 참여한 파티 중 t+1개 이상이 있어야 합니다.
+func (s *Session) Sign(ctx context.Context, share *KeyShare, hash []byte) (*common.SignatureData, error) {
+	if len(s.ids) <= share.Threshold {
+		return nil, fmt.Errorf("signing requires at least %d parties, got %d", share.Threshold+1, len(s.ids))
+	}
+	for _, id := range s.ids {
+		if !share.HasParty(id.Id) {
+			return nil, fmt.Errorf("party %s does not hold a share of key %s", id.Id, share.KeyID)
+		}
+	}
+
+	msg := new(big.Int).SetBytes(hash)
+	end := make(chan *common.SignatureData, 1)
+
+	// 서명 참여 파티에 해당하는 데이터만 골라 사용합니다.
+	var party tsslib.Party
+	switch share.Curve {
+	case Secp256k1:
+		data := ecdsakeygen.BuildLocalSaveDataSubset(*share.ECDSA, s.ids)
+		params := tsslib.NewParameters(tsslib.S256(), tsslib.NewPeerContext(s.ids), s.self, len(s.ids), share.Threshold)
+		party = ecdsasigning.NewLocalParty(msg, params, data, s.out, end, len(hash))
+	case Ed25519:
+		data := eddsakeygen.BuildLocalSaveDataSubset(*share.EdDSA, s.ids)
+		params := tsslib.NewParameters(tsslib.Edwards(), tsslib.NewPeerContext(s.ids), s.self, len(s.ids), share.Threshold)
+		party = eddsasigning.NewLocalParty(msg, params, data, s.out, end, len(hash))
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", share.Curve)
+	}
+
+	return run(ctx, s, party, end)
+}
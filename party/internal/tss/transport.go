@@ -0,0 +1,19 @@
+package tss
+
+import "context"
+
+// Message는 파티 사이에 전달되는 tss-lib 라운드 메시지입니다.
+// Seq는 같은 세션에서 From이 To에게 보낸 메시지의 순번으로 0부터 시작합니다.
+type Message struct {
+	SessionID   string
+	From        string
+	To          string
+	IsBroadcast bool
+	Seq         uint64
+	Payload     []byte
+}
+
+// Transport는 다른 파티에게 라운드 메시지를 전달합니다.
+type Transport interface {
+	Send(ctx context.Context, to Peer, msg *Message) error
+}
@@ -0,0 +1,1789 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: tss/v1/tss.proto
+
+// tss.v1은 게이트웨이와 파티가 함께 사용하는 API입니다.
+// 필드 번호는 재사용하지 않으며, 호환되지 않는 변경은 새 버전 패키지(tss.v2)로 추가합니다.
+
+package tssv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RoutingMode는 파티 사이의 라운드 메시지 전달 방식입니다.
+type RoutingMode int32
+
+const (
+	RoutingMode_ROUTING_MODE_DIRECT RoutingMode = 0
+	RoutingMode_ROUTING_MODE_RELAY  RoutingMode = 1
+)
+
+// Enum value maps for RoutingMode.
+var (
+	RoutingMode_name = map[int32]string{
+		0: "ROUTING_MODE_DIRECT",
+		1: "ROUTING_MODE_RELAY",
+	}
+	RoutingMode_value = map[string]int32{
+		"ROUTING_MODE_DIRECT": 0,
+		"ROUTING_MODE_RELAY":  1,
+	}
+)
+
+func (x RoutingMode) Enum() *RoutingMode {
+	p := new(RoutingMode)
+	*p = x
+	return p
+}
+
+func (x RoutingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoutingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_tss_v1_tss_proto_enumTypes[0].Descriptor()
+}
+
+func (RoutingMode) Type() protoreflect.EnumType {
+	return &file_tss_v1_tss_proto_enumTypes[0]
+}
+
+func (x RoutingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoutingMode.Descriptor instead.
+func (RoutingMode) EnumDescriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{0}
+}
+
+// Curve는 키가 사용하는 곡선입니다. secp256k1은 ECDSA, ed25519는 EdDSA 프로토콜을 사용합니다.
+type Curve int32
+
+const (
+	Curve_CURVE_SECP256K1 Curve = 0
+	Curve_CURVE_ED25519   Curve = 1
+)
+
+// Enum value maps for Curve.
+var (
+	Curve_name = map[int32]string{
+		0: "CURVE_SECP256K1",
+		1: "CURVE_ED25519",
+	}
+	Curve_value = map[string]int32{
+		"CURVE_SECP256K1": 0,
+		"CURVE_ED25519":   1,
+	}
+)
+
+func (x Curve) Enum() *Curve {
+	p := new(Curve)
+	*p = x
+	return p
+}
+
+func (x Curve) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Curve) Descriptor() protoreflect.EnumDescriptor {
+	return file_tss_v1_tss_proto_enumTypes[1].Descriptor()
+}
+
+func (Curve) Type() protoreflect.EnumType {
+	return &file_tss_v1_tss_proto_enumTypes[1]
+}
+
+func (x Curve) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Curve.Descriptor instead.
+func (Curve) EnumDescriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{1}
+}
+
+// party_key는 tss-lib PartyID 키입니다. 비어 있으면 파티 이름의 SHA-256을 사용합니다.
+// token_hash는 게이트웨이가 세션마다 이 파티에게 발급한 토큰(relay-token 메타데이터)의 SHA-256입니다.
+// direct 모드에서 파티는 SendMessage에 자신의 토큰을 붙이고, 받는 파티는 from의 token_hash로 발신자를 확인합니다.
+type PodInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip        string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port      int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PartyKey  []byte `protobuf:"bytes,4,opt,name=party_key,json=partyKey,proto3" json:"party_key,omitempty"`
+	TokenHash []byte `protobuf:"bytes,5,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+}
+
+func (x *PodInfo) Reset() {
+	*x = PodInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodInfo) ProtoMessage() {}
+
+func (x *PodInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodInfo.ProtoReflect.Descriptor instead.
+func (*PodInfo) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PodInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PodInfo) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PodInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PodInfo) GetPartyKey() []byte {
+	if x != nil {
+		return x.PartyKey
+	}
+	return nil
+}
+
+func (x *PodInfo) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+type KeygenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N         int32       `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	M         int32       `protobuf:"varint,2,opt,name=m,proto3" json:"m,omitempty"`
+	Pods      []*PodInfo  `protobuf:"bytes,3,rep,name=pods,proto3" json:"pods,omitempty"`
+	SessionId string      `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Routing   RoutingMode `protobuf:"varint,5,opt,name=routing,proto3,enum=tss.v1.RoutingMode" json:"routing,omitempty"`
+	KeyId     string      `protobuf:"bytes,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Curve     Curve       `protobuf:"varint,7,opt,name=curve,proto3,enum=tss.v1.Curve" json:"curve,omitempty"`
+}
+
+func (x *KeygenRequest) Reset() {
+	*x = KeygenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRequest) ProtoMessage() {}
+
+func (x *KeygenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRequest.ProtoReflect.Descriptor instead.
+func (*KeygenRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeygenRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *KeygenRequest) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *KeygenRequest) GetPods() []*PodInfo {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *KeygenRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *KeygenRequest) GetRouting() RoutingMode {
+	if x != nil {
+		return x.Routing
+	}
+	return RoutingMode_ROUTING_MODE_DIRECT
+}
+
+func (x *KeygenRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *KeygenRequest) GetCurve() Curve {
+	if x != nil {
+		return x.Curve
+	}
+	return Curve_CURVE_SECP256K1
+}
+
+// publickey는 곡선에 맞게 인코딩된 공개키의 hex 문자열입니다.
+// secp256k1은 33바이트 압축 형식, ed25519는 32바이트 형식입니다.
+type KeygenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Publickey string `protobuf:"bytes,1,opt,name=publickey,proto3" json:"publickey,omitempty"`
+}
+
+func (x *KeygenResponse) Reset() {
+	*x = KeygenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenResponse) ProtoMessage() {}
+
+func (x *KeygenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenResponse.ProtoReflect.Descriptor instead.
+func (*KeygenResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KeygenResponse) GetPublickey() string {
+	if x != nil {
+		return x.Publickey
+	}
+	return ""
+}
+
+// SignRequest의 pods는 키 생성에 참여한 파티 중 서명에 참여할 t+1개 이상의 파티입니다.
+// message는 서명할 32바이트 메시지 해시입니다.
+// generation은 게이트웨이가 확정한 키 조각 세대입니다. 파티는 같은 세대의 조각으로만 서명합니다.
+type SignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId  string      `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	KeyId      string      `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Message    []byte      `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Pods       []*PodInfo  `protobuf:"bytes,4,rep,name=pods,proto3" json:"pods,omitempty"`
+	Routing    RoutingMode `protobuf:"varint,5,opt,name=routing,proto3,enum=tss.v1.RoutingMode" json:"routing,omitempty"`
+	Generation string      `protobuf:"bytes,6,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SignRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SignRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SignRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SignRequest) GetPods() []*PodInfo {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *SignRequest) GetRouting() RoutingMode {
+	if x != nil {
+		return x.Routing
+	}
+	return RoutingMode_ROUTING_MODE_DIRECT
+}
+
+func (x *SignRequest) GetGeneration() string {
+	if x != nil {
+		return x.Generation
+	}
+	return ""
+}
+
+// signature는 r과 s를 이어 붙인 64바이트 서명이며, recovery_id는 ECDSA에서만 의미가 있습니다.
+type SignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	R          []byte `protobuf:"bytes,1,opt,name=r,proto3" json:"r,omitempty"`
+	S          []byte `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+	RecoveryId int32  `protobuf:"varint,3,opt,name=recovery_id,json=recoveryId,proto3" json:"recovery_id,omitempty"`
+	Signature  []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignResponse) GetR() []byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *SignResponse) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *SignResponse) GetRecoveryId() int32 {
+	if x != nil {
+		return x.RecoveryId
+	}
+	return 0
+}
+
+func (x *SignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// ReshareRequest는 old_pods가 가진 key_id의 키 조각을 공개키를 유지한 채 new_pods로 옮깁니다.
+// 새 위원회 파티는 새 조각을 session_id 세대로 준비만 해 두고, 게이트웨이가 CommitShare로 확정하거나
+// AbortShare로 버립니다. 확정 전까지는 기존 조각이 그대로 쓰입니다.
+// generation은 기존 위원회가 사용할 키의 현재 세대입니다.
+// 두 위원회에 모두 속한 파티는 역할마다 다른 party_key로 참여하며,
+// 새 위원회 역할의 라운드 메시지 from/to에는 "<이름>#new"를 사용합니다.
+type ReshareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId    string      `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	KeyId        string      `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Curve        Curve       `protobuf:"varint,3,opt,name=curve,proto3,enum=tss.v1.Curve" json:"curve,omitempty"`
+	OldPods      []*PodInfo  `protobuf:"bytes,4,rep,name=old_pods,json=oldPods,proto3" json:"old_pods,omitempty"`
+	OldThreshold int32       `protobuf:"varint,5,opt,name=old_threshold,json=oldThreshold,proto3" json:"old_threshold,omitempty"`
+	NewPods      []*PodInfo  `protobuf:"bytes,6,rep,name=new_pods,json=newPods,proto3" json:"new_pods,omitempty"`
+	NewThreshold int32       `protobuf:"varint,7,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty"`
+	Routing      RoutingMode `protobuf:"varint,8,opt,name=routing,proto3,enum=tss.v1.RoutingMode" json:"routing,omitempty"`
+	Generation   string      `protobuf:"bytes,9,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *ReshareRequest) Reset() {
+	*x = ReshareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReshareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshareRequest) ProtoMessage() {}
+
+func (x *ReshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshareRequest.ProtoReflect.Descriptor instead.
+func (*ReshareRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReshareRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ReshareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ReshareRequest) GetCurve() Curve {
+	if x != nil {
+		return x.Curve
+	}
+	return Curve_CURVE_SECP256K1
+}
+
+func (x *ReshareRequest) GetOldPods() []*PodInfo {
+	if x != nil {
+		return x.OldPods
+	}
+	return nil
+}
+
+func (x *ReshareRequest) GetOldThreshold() int32 {
+	if x != nil {
+		return x.OldThreshold
+	}
+	return 0
+}
+
+func (x *ReshareRequest) GetNewPods() []*PodInfo {
+	if x != nil {
+		return x.NewPods
+	}
+	return nil
+}
+
+func (x *ReshareRequest) GetNewThreshold() int32 {
+	if x != nil {
+		return x.NewThreshold
+	}
+	return 0
+}
+
+func (x *ReshareRequest) GetRouting() RoutingMode {
+	if x != nil {
+		return x.Routing
+	}
+	return RoutingMode_ROUTING_MODE_DIRECT
+}
+
+func (x *ReshareRequest) GetGeneration() string {
+	if x != nil {
+		return x.Generation
+	}
+	return ""
+}
+
+// 새 위원회 파티는 새 조각의 공개키를, 기존 위원회에만 속한 파티는 빈 값을 반환합니다.
+type ReshareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Publickey string `protobuf:"bytes,1,opt,name=publickey,proto3" json:"publickey,omitempty"`
+}
+
+func (x *ReshareResponse) Reset() {
+	*x = ReshareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReshareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReshareResponse) ProtoMessage() {}
+
+func (x *ReshareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReshareResponse.ProtoReflect.Descriptor instead.
+func (*ReshareResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReshareResponse) GetPublickey() string {
+	if x != nil {
+		return x.Publickey
+	}
+	return ""
+}
+
+// CommitShareRequest는 재공유로 준비된 generation 세대의 조각을 현재 조각으로 확정합니다.
+type CommitShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId      string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Generation string `protobuf:"bytes,2,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *CommitShareRequest) Reset() {
+	*x = CommitShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitShareRequest) ProtoMessage() {}
+
+func (x *CommitShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitShareRequest.ProtoReflect.Descriptor instead.
+func (*CommitShareRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommitShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *CommitShareRequest) GetGeneration() string {
+	if x != nil {
+		return x.Generation
+	}
+	return ""
+}
+
+type CommitShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommitShareResponse) Reset() {
+	*x = CommitShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitShareResponse) ProtoMessage() {}
+
+func (x *CommitShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitShareResponse.ProtoReflect.Descriptor instead.
+func (*CommitShareResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{8}
+}
+
+// AbortShareRequest는 실패한 재공유로 준비된 generation 세대의 조각을 버립니다.
+type AbortShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId      string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Generation string `protobuf:"bytes,2,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *AbortShareRequest) Reset() {
+	*x = AbortShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortShareRequest) ProtoMessage() {}
+
+func (x *AbortShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortShareRequest.ProtoReflect.Descriptor instead.
+func (*AbortShareRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AbortShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *AbortShareRequest) GetGeneration() string {
+	if x != nil {
+		return x.Generation
+	}
+	return ""
+}
+
+type AbortShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AbortShareResponse) Reset() {
+	*x = AbortShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortShareResponse) ProtoMessage() {}
+
+func (x *AbortShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortShareResponse.ProtoReflect.Descriptor instead.
+func (*AbortShareResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{10}
+}
+
+// DeleteShareRequest는 재공유로 새 위원회에서 빠진 파티의 키 조각을 삭제합니다.
+type DeleteShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+}
+
+func (x *DeleteShareRequest) Reset() {
+	*x = DeleteShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareRequest) ProtoMessage() {}
+
+func (x *DeleteShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShareRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type DeleteShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteShareResponse) Reset() {
+	*x = DeleteShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareResponse) ProtoMessage() {}
+
+func (x *DeleteShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShareResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{12}
+}
+
+type KeygenFinishedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Publickey string `protobuf:"bytes,1,opt,name=publickey,proto3" json:"publickey,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Party     string `protobuf:"bytes,3,opt,name=party,proto3" json:"party,omitempty"`
+}
+
+func (x *KeygenFinishedRequest) Reset() {
+	*x = KeygenFinishedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenFinishedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenFinishedRequest) ProtoMessage() {}
+
+func (x *KeygenFinishedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenFinishedRequest.ProtoReflect.Descriptor instead.
+func (*KeygenFinishedRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *KeygenFinishedRequest) GetPublickey() string {
+	if x != nil {
+		return x.Publickey
+	}
+	return ""
+}
+
+func (x *KeygenFinishedRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *KeygenFinishedRequest) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+type KeygenFinishedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *KeygenFinishedResponse) Reset() {
+	*x = KeygenFinishedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenFinishedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenFinishedResponse) ProtoMessage() {}
+
+func (x *KeygenFinishedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenFinishedResponse.ProtoReflect.Descriptor instead.
+func (*KeygenFinishedResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *KeygenFinishedResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// KeygenProgressRequest는 파티가 새 라운드에 들어설 때 게이트웨이에 보내는 진행 보고입니다.
+type KeygenProgressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Party     string `protobuf:"bytes,2,opt,name=party,proto3" json:"party,omitempty"`
+	Round     int32  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (x *KeygenProgressRequest) Reset() {
+	*x = KeygenProgressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenProgressRequest) ProtoMessage() {}
+
+func (x *KeygenProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenProgressRequest.ProtoReflect.Descriptor instead.
+func (*KeygenProgressRequest) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KeygenProgressRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *KeygenProgressRequest) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+func (x *KeygenProgressRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type KeygenProgressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *KeygenProgressResponse) Reset() {
+	*x = KeygenProgressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenProgressResponse) ProtoMessage() {}
+
+func (x *KeygenProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenProgressResponse.ProtoReflect.Descriptor instead.
+func (*KeygenProgressResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{16}
+}
+
+type RoundMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId   string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	From        string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To          string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	IsBroadcast bool   `protobuf:"varint,4,opt,name=is_broadcast,json=isBroadcast,proto3" json:"is_broadcast,omitempty"`
+	Seq         uint64 `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	Payload     []byte `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *RoundMessage) Reset() {
+	*x = RoundMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoundMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundMessage) ProtoMessage() {}
+
+func (x *RoundMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundMessage.ProtoReflect.Descriptor instead.
+func (*RoundMessage) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RoundMessage) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RoundMessage) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RoundMessage) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RoundMessage) GetIsBroadcast() bool {
+	if x != nil {
+		return x.IsBroadcast
+	}
+	return false
+}
+
+func (x *RoundMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *RoundMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_v1_tss_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_v1_tss_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_tss_v1_tss_proto_rawDescGZIP(), []int{18}
+}
+
+var File_tss_v1_tss_proto protoreflect.FileDescriptor
+
+var file_tss_v1_tss_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x74, 0x73, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x7d, 0x0a, 0x07, 0x50, 0x6f,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x72, 0x74, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x70, 0x61, 0x72, 0x74, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x4b, 0x65,
+	0x79, 0x67, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74,
+	0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49,
+	0x64, 0x12, 0x23, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0d, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x52,
+	0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x22, 0x2e, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x6b, 0x65, 0x79, 0x22, 0xd1, 0x01, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74,
+	0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x0c, 0x53, 0x69,
+	0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xdc, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e,
+	0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x52, 0x05, 0x63, 0x75,
+	0x72, 0x76, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x50, 0x6f, 0x64, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x6f, 0x6c, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6f, 0x6c, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x6f, 0x64, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6f, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x6f, 0x64, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x6b, 0x65, 0x79, 0x22, 0x4b, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x11, 0x41, 0x62, 0x6f,
+	0x72, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x0a, 0x12, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x6a, 0x0a, 0x15, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x6b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x22, 0x32, 0x0a, 0x16, 0x4b,
+	0x65, 0x79, 0x67, 0x65, 0x6e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x62, 0x0a, 0x15, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa0, 0x01,
+	0x0a, 0x0c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74,
+	0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0x15, 0x0a, 0x13, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x3e, 0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x4f, 0x55, 0x54, 0x49, 0x4e,
+	0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12,
+	0x16, 0x0a, 0x12, 0x52, 0x4f, 0x55, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x52, 0x45, 0x4c, 0x41, 0x59, 0x10, 0x01, 0x2a, 0x2f, 0x0a, 0x05, 0x43, 0x75, 0x72, 0x76, 0x65,
+	0x12, 0x13, 0x0a, 0x0f, 0x43, 0x55, 0x52, 0x56, 0x45, 0x5f, 0x53, 0x45, 0x43, 0x50, 0x32, 0x35,
+	0x36, 0x4b, 0x31, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x55, 0x52, 0x56, 0x45, 0x5f, 0x45,
+	0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x10, 0x01, 0x32, 0xb5, 0x04, 0x0a, 0x0d, 0x4b, 0x65, 0x79,
+	0x67, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x15, 0x2e, 0x74, 0x73, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e,
+	0x12, 0x13, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x52,
+	0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x43, 0x0a, 0x0a, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x19, 0x2e,
+	0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x12, 0x1a, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e,
+	0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x1d,
+	0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x46, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x46, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12,
+	0x4f, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x1d, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65,
+	0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x32, 0x50, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x40, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1b, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x32, 0x47, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x37, 0x0a, 0x05, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x14, 0x2e, 0x74, 0x73,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x14, 0x2e, 0x74, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x14, 0x5a, 0x12, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x73, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x74, 0x73, 0x73, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tss_v1_tss_proto_rawDescOnce sync.Once
+	file_tss_v1_tss_proto_rawDescData = file_tss_v1_tss_proto_rawDesc
+)
+
+func file_tss_v1_tss_proto_rawDescGZIP() []byte {
+	file_tss_v1_tss_proto_rawDescOnce.Do(func() {
+		file_tss_v1_tss_proto_rawDescData = protoimpl.X.CompressGZIP(file_tss_v1_tss_proto_rawDescData)
+	})
+	return file_tss_v1_tss_proto_rawDescData
+}
+
+var file_tss_v1_tss_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tss_v1_tss_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_tss_v1_tss_proto_goTypes = []any{
+	(RoutingMode)(0),               // 0: tss.v1.RoutingMode
+	(Curve)(0),                     // 1: tss.v1.Curve
+	(*PodInfo)(nil),                // 2: tss.v1.PodInfo
+	(*KeygenRequest)(nil),          // 3: tss.v1.KeygenRequest
+	(*KeygenResponse)(nil),         // 4: tss.v1.KeygenResponse
+	(*SignRequest)(nil),            // 5: tss.v1.SignRequest
+	(*SignResponse)(nil),           // 6: tss.v1.SignResponse
+	(*ReshareRequest)(nil),         // 7: tss.v1.ReshareRequest
+	(*ReshareResponse)(nil),        // 8: tss.v1.ReshareResponse
+	(*CommitShareRequest)(nil),     // 9: tss.v1.CommitShareRequest
+	(*CommitShareResponse)(nil),    // 10: tss.v1.CommitShareResponse
+	(*AbortShareRequest)(nil),      // 11: tss.v1.AbortShareRequest
+	(*AbortShareResponse)(nil),     // 12: tss.v1.AbortShareResponse
+	(*DeleteShareRequest)(nil),     // 13: tss.v1.DeleteShareRequest
+	(*DeleteShareResponse)(nil),    // 14: tss.v1.DeleteShareResponse
+	(*KeygenFinishedRequest)(nil),  // 15: tss.v1.KeygenFinishedRequest
+	(*KeygenFinishedResponse)(nil), // 16: tss.v1.KeygenFinishedResponse
+	(*KeygenProgressRequest)(nil),  // 17: tss.v1.KeygenProgressRequest
+	(*KeygenProgressResponse)(nil), // 18: tss.v1.KeygenProgressResponse
+	(*RoundMessage)(nil),           // 19: tss.v1.RoundMessage
+	(*SendMessageResponse)(nil),    // 20: tss.v1.SendMessageResponse
+}
+var file_tss_v1_tss_proto_depIdxs = []int32{
+	2,  // 0: tss.v1.KeygenRequest.pods:type_name -> tss.v1.PodInfo
+	0,  // 1: tss.v1.KeygenRequest.routing:type_name -> tss.v1.RoutingMode
+	1,  // 2: tss.v1.KeygenRequest.curve:type_name -> tss.v1.Curve
+	2,  // 3: tss.v1.SignRequest.pods:type_name -> tss.v1.PodInfo
+	0,  // 4: tss.v1.SignRequest.routing:type_name -> tss.v1.RoutingMode
+	1,  // 5: tss.v1.ReshareRequest.curve:type_name -> tss.v1.Curve
+	2,  // 6: tss.v1.ReshareRequest.old_pods:type_name -> tss.v1.PodInfo
+	2,  // 7: tss.v1.ReshareRequest.new_pods:type_name -> tss.v1.PodInfo
+	0,  // 8: tss.v1.ReshareRequest.routing:type_name -> tss.v1.RoutingMode
+	3,  // 9: tss.v1.KeygenService.GenerateKey:input_type -> tss.v1.KeygenRequest
+	5,  // 10: tss.v1.KeygenService.Sign:input_type -> tss.v1.SignRequest
+	7,  // 11: tss.v1.KeygenService.Reshare:input_type -> tss.v1.ReshareRequest
+	9,  // 12: tss.v1.KeygenService.CommitShare:input_type -> tss.v1.CommitShareRequest
+	11, // 13: tss.v1.KeygenService.AbortShare:input_type -> tss.v1.AbortShareRequest
+	13, // 14: tss.v1.KeygenService.DeleteShare:input_type -> tss.v1.DeleteShareRequest
+	15, // 15: tss.v1.KeygenService.KeygenFinished:input_type -> tss.v1.KeygenFinishedRequest
+	17, // 16: tss.v1.KeygenService.KeygenProgress:input_type -> tss.v1.KeygenProgressRequest
+	19, // 17: tss.v1.PartyService.SendMessage:input_type -> tss.v1.RoundMessage
+	19, // 18: tss.v1.RelayService.Relay:input_type -> tss.v1.RoundMessage
+	4,  // 19: tss.v1.KeygenService.GenerateKey:output_type -> tss.v1.KeygenResponse
+	6,  // 20: tss.v1.KeygenService.Sign:output_type -> tss.v1.SignResponse
+	8,  // 21: tss.v1.KeygenService.Reshare:output_type -> tss.v1.ReshareResponse
+	10, // 22: tss.v1.KeygenService.CommitShare:output_type -> tss.v1.CommitShareResponse
+	12, // 23: tss.v1.KeygenService.AbortShare:output_type -> tss.v1.AbortShareResponse
+	14, // 24: tss.v1.KeygenService.DeleteShare:output_type -> tss.v1.DeleteShareResponse
+	16, // 25: tss.v1.KeygenService.KeygenFinished:output_type -> tss.v1.KeygenFinishedResponse
+	18, // 26: tss.v1.KeygenService.KeygenProgress:output_type -> tss.v1.KeygenProgressResponse
+	20, // 27: tss.v1.PartyService.SendMessage:output_type -> tss.v1.SendMessageResponse
+	19, // 28: tss.v1.RelayService.Relay:output_type -> tss.v1.RoundMessage
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_tss_v1_tss_proto_init() }
+func file_tss_v1_tss_proto_init() {
+	if File_tss_v1_tss_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tss_v1_tss_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*PodInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ReshareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ReshareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CommitShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*CommitShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*AbortShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*AbortShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenFinishedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenFinishedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenProgressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*KeygenProgressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*RoundMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_v1_tss_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SendMessageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tss_v1_tss_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_tss_v1_tss_proto_goTypes,
+		DependencyIndexes: file_tss_v1_tss_proto_depIdxs,
+		EnumInfos:         file_tss_v1_tss_proto_enumTypes,
+		MessageInfos:      file_tss_v1_tss_proto_msgTypes,
+	}.Build()
+	File_tss_v1_tss_proto = out.File
+	file_tss_v1_tss_proto_rawDesc = nil
+	file_tss_v1_tss_proto_goTypes = nil
+	file_tss_v1_tss_proto_depIdxs = nil
+}
@@ -0,0 +1,640 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.1
+// source: tss/v1/tss.proto
+
+// tss.v1은 게이트웨이와 파티가 함께 사용하는 API입니다.
+// 필드 번호는 재사용하지 않으며, 호환되지 않는 변경은 새 버전 패키지(tss.v2)로 추가합니다.
+
+package tssv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	KeygenService_GenerateKey_FullMethodName    = "/tss.v1.KeygenService/GenerateKey"
+	KeygenService_Sign_FullMethodName           = "/tss.v1.KeygenService/Sign"
+	KeygenService_Reshare_FullMethodName        = "/tss.v1.KeygenService/Reshare"
+	KeygenService_CommitShare_FullMethodName    = "/tss.v1.KeygenService/CommitShare"
+	KeygenService_AbortShare_FullMethodName     = "/tss.v1.KeygenService/AbortShare"
+	KeygenService_DeleteShare_FullMethodName    = "/tss.v1.KeygenService/DeleteShare"
+	KeygenService_KeygenFinished_FullMethodName = "/tss.v1.KeygenService/KeygenFinished"
+	KeygenService_KeygenProgress_FullMethodName = "/tss.v1.KeygenService/KeygenProgress"
+)
+
+// KeygenServiceClient is the client API for KeygenService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeygenServiceClient interface {
+	GenerateKey(ctx context.Context, in *KeygenRequest, opts ...grpc.CallOption) (*KeygenResponse, error)
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	Reshare(ctx context.Context, in *ReshareRequest, opts ...grpc.CallOption) (*ReshareResponse, error)
+	CommitShare(ctx context.Context, in *CommitShareRequest, opts ...grpc.CallOption) (*CommitShareResponse, error)
+	AbortShare(ctx context.Context, in *AbortShareRequest, opts ...grpc.CallOption) (*AbortShareResponse, error)
+	DeleteShare(ctx context.Context, in *DeleteShareRequest, opts ...grpc.CallOption) (*DeleteShareResponse, error)
+	KeygenFinished(ctx context.Context, opts ...grpc.CallOption) (KeygenService_KeygenFinishedClient, error)
+	KeygenProgress(ctx context.Context, in *KeygenProgressRequest, opts ...grpc.CallOption) (*KeygenProgressResponse, error)
+}
+
+type keygenServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeygenServiceClient(cc grpc.ClientConnInterface) KeygenServiceClient {
+	return &keygenServiceClient{cc}
+}
+
+func (c *keygenServiceClient) GenerateKey(ctx context.Context, in *KeygenRequest, opts ...grpc.CallOption) (*KeygenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeygenResponse)
+	err := c.cc.Invoke(ctx, KeygenService_GenerateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, KeygenService_Sign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) Reshare(ctx context.Context, in *ReshareRequest, opts ...grpc.CallOption) (*ReshareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReshareResponse)
+	err := c.cc.Invoke(ctx, KeygenService_Reshare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) CommitShare(ctx context.Context, in *CommitShareRequest, opts ...grpc.CallOption) (*CommitShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitShareResponse)
+	err := c.cc.Invoke(ctx, KeygenService_CommitShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) AbortShare(ctx context.Context, in *AbortShareRequest, opts ...grpc.CallOption) (*AbortShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortShareResponse)
+	err := c.cc.Invoke(ctx, KeygenService_AbortShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) DeleteShare(ctx context.Context, in *DeleteShareRequest, opts ...grpc.CallOption) (*DeleteShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShareResponse)
+	err := c.cc.Invoke(ctx, KeygenService_DeleteShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keygenServiceClient) KeygenFinished(ctx context.Context, opts ...grpc.CallOption) (KeygenService_KeygenFinishedClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeygenService_ServiceDesc.Streams[0], KeygenService_KeygenFinished_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &keygenServiceKeygenFinishedClient{ClientStream: stream}
+	return x, nil
+}
+
+type KeygenService_KeygenFinishedClient interface {
+	Send(*KeygenFinishedRequest) error
+	CloseAndRecv() (*KeygenFinishedResponse, error)
+	grpc.ClientStream
+}
+
+type keygenServiceKeygenFinishedClient struct {
+	grpc.ClientStream
+}
+
+func (x *keygenServiceKeygenFinishedClient) Send(m *KeygenFinishedRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *keygenServiceKeygenFinishedClient) CloseAndRecv() (*KeygenFinishedResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(KeygenFinishedResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *keygenServiceClient) KeygenProgress(ctx context.Context, in *KeygenProgressRequest, opts ...grpc.CallOption) (*KeygenProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeygenProgressResponse)
+	err := c.cc.Invoke(ctx, KeygenService_KeygenProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeygenServiceServer is the server API for KeygenService service.
+// All implementations must embed UnimplementedKeygenServiceServer
+// for forward compatibility
+type KeygenServiceServer interface {
+	GenerateKey(context.Context, *KeygenRequest) (*KeygenResponse, error)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	Reshare(context.Context, *ReshareRequest) (*ReshareResponse, error)
+	CommitShare(context.Context, *CommitShareRequest) (*CommitShareResponse, error)
+	AbortShare(context.Context, *AbortShareRequest) (*AbortShareResponse, error)
+	DeleteShare(context.Context, *DeleteShareRequest) (*DeleteShareResponse, error)
+	KeygenFinished(KeygenService_KeygenFinishedServer) error
+	KeygenProgress(context.Context, *KeygenProgressRequest) (*KeygenProgressResponse, error)
+	mustEmbedUnimplementedKeygenServiceServer()
+}
+
+// UnimplementedKeygenServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedKeygenServiceServer struct {
+}
+
+func (UnimplementedKeygenServiceServer) GenerateKey(context.Context, *KeygenRequest) (*KeygenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateKey not implemented")
+}
+func (UnimplementedKeygenServiceServer) Sign(context.Context, *SignRequest) (*SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (UnimplementedKeygenServiceServer) Reshare(context.Context, *ReshareRequest) (*ReshareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reshare not implemented")
+}
+func (UnimplementedKeygenServiceServer) CommitShare(context.Context, *CommitShareRequest) (*CommitShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitShare not implemented")
+}
+func (UnimplementedKeygenServiceServer) AbortShare(context.Context, *AbortShareRequest) (*AbortShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortShare not implemented")
+}
+func (UnimplementedKeygenServiceServer) DeleteShare(context.Context, *DeleteShareRequest) (*DeleteShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShare not implemented")
+}
+func (UnimplementedKeygenServiceServer) KeygenFinished(KeygenService_KeygenFinishedServer) error {
+	return status.Errorf(codes.Unimplemented, "method KeygenFinished not implemented")
+}
+func (UnimplementedKeygenServiceServer) KeygenProgress(context.Context, *KeygenProgressRequest) (*KeygenProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeygenProgress not implemented")
+}
+func (UnimplementedKeygenServiceServer) mustEmbedUnimplementedKeygenServiceServer() {}
+
+// UnsafeKeygenServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeygenServiceServer will
+// result in compilation errors.
+type UnsafeKeygenServiceServer interface {
+	mustEmbedUnimplementedKeygenServiceServer()
+}
+
+func RegisterKeygenServiceServer(s grpc.ServiceRegistrar, srv KeygenServiceServer) {
+	s.RegisterService(&KeygenService_ServiceDesc, srv)
+}
+
+func _KeygenService_GenerateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeygenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).GenerateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_GenerateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).GenerateKey(ctx, req.(*KeygenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_Sign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_Reshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).Reshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_Reshare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).Reshare(ctx, req.(*ReshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_CommitShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).CommitShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_CommitShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).CommitShare(ctx, req.(*CommitShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_AbortShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).AbortShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_AbortShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).AbortShare(ctx, req.(*AbortShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_DeleteShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).DeleteShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_DeleteShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).DeleteShare(ctx, req.(*DeleteShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeygenService_KeygenFinished_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KeygenServiceServer).KeygenFinished(&keygenServiceKeygenFinishedServer{ServerStream: stream})
+}
+
+type KeygenService_KeygenFinishedServer interface {
+	SendAndClose(*KeygenFinishedResponse) error
+	Recv() (*KeygenFinishedRequest, error)
+	grpc.ServerStream
+}
+
+type keygenServiceKeygenFinishedServer struct {
+	grpc.ServerStream
+}
+
+func (x *keygenServiceKeygenFinishedServer) SendAndClose(m *KeygenFinishedResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *keygenServiceKeygenFinishedServer) Recv() (*KeygenFinishedRequest, error) {
+	m := new(KeygenFinishedRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _KeygenService_KeygenProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeygenProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeygenServiceServer).KeygenProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeygenService_KeygenProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeygenServiceServer).KeygenProgress(ctx, req.(*KeygenProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeygenService_ServiceDesc is the grpc.ServiceDesc for KeygenService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeygenService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tss.v1.KeygenService",
+	HandlerType: (*KeygenServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateKey",
+			Handler:    _KeygenService_GenerateKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _KeygenService_Sign_Handler,
+		},
+		{
+			MethodName: "Reshare",
+			Handler:    _KeygenService_Reshare_Handler,
+		},
+		{
+			MethodName: "CommitShare",
+			Handler:    _KeygenService_CommitShare_Handler,
+		},
+		{
+			MethodName: "AbortShare",
+			Handler:    _KeygenService_AbortShare_Handler,
+		},
+		{
+			MethodName: "DeleteShare",
+			Handler:    _KeygenService_DeleteShare_Handler,
+		},
+		{
+			MethodName: "KeygenProgress",
+			Handler:    _KeygenService_KeygenProgress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "KeygenFinished",
+			Handler:       _KeygenService_KeygenFinished_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "tss/v1/tss.proto",
+}
+
+const (
+	PartyService_SendMessage_FullMethodName = "/tss.v1.PartyService/SendMessage"
+)
+
+// PartyServiceClient is the client API for PartyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PartyService는 파티 사이에 tss-lib 라운드 메시지를 전달합니다.
+// 보내는 파티는 relay-token 메타데이터에 게이트웨이가 발급한 세션 토큰을 붙이며, 토큰이 from과 맞지 않는 메시지는 PermissionDenied로 거부됩니다.
+type PartyServiceClient interface {
+	SendMessage(ctx context.Context, in *RoundMessage, opts ...grpc.CallOption) (*SendMessageResponse, error)
+}
+
+type partyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPartyServiceClient(cc grpc.ClientConnInterface) PartyServiceClient {
+	return &partyServiceClient{cc}
+}
+
+func (c *partyServiceClient) SendMessage(ctx context.Context, in *RoundMessage, opts ...grpc.CallOption) (*SendMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMessageResponse)
+	err := c.cc.Invoke(ctx, PartyService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PartyServiceServer is the server API for PartyService service.
+// All implementations must embed UnimplementedPartyServiceServer
+// for forward compatibility
+//
+// PartyService는 파티 사이에 tss-lib 라운드 메시지를 전달합니다.
+// 보내는 파티는 relay-token 메타데이터에 게이트웨이가 발급한 세션 토큰을 붙이며, 토큰이 from과 맞지 않는 메시지는 PermissionDenied로 거부됩니다.
+type PartyServiceServer interface {
+	SendMessage(context.Context, *RoundMessage) (*SendMessageResponse, error)
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+// UnimplementedPartyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPartyServiceServer struct {
+}
+
+func (UnimplementedPartyServiceServer) SendMessage(context.Context, *RoundMessage) (*SendMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedPartyServiceServer) mustEmbedUnimplementedPartyServiceServer() {}
+
+// UnsafePartyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PartyServiceServer will
+// result in compilation errors.
+type UnsafePartyServiceServer interface {
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+func RegisterPartyServiceServer(s grpc.ServiceRegistrar, srv PartyServiceServer) {
+	s.RegisterService(&PartyService_ServiceDesc, srv)
+}
+
+func _PartyService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoundMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).SendMessage(ctx, req.(*RoundMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PartyService_ServiceDesc is the grpc.ServiceDesc for PartyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PartyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tss.v1.PartyService",
+	HandlerType: (*PartyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendMessage",
+			Handler:    _PartyService_SendMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tss/v1/tss.proto",
+}
+
+const (
+	RelayService_Relay_FullMethodName = "/tss.v1.RelayService/Relay"
+)
+
+// RelayServiceClient is the client API for RelayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RelayService는 게이트웨이를 거쳐 파티 사이의 라운드 메시지를 중계합니다.
+// 파티는 "party" 메타데이터에 자신의 이름을 담아 스트림을 엽니다.
+type RelayServiceClient interface {
+	Relay(ctx context.Context, opts ...grpc.CallOption) (RelayService_RelayClient, error)
+}
+
+type relayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelayServiceClient(cc grpc.ClientConnInterface) RelayServiceClient {
+	return &relayServiceClient{cc}
+}
+
+func (c *relayServiceClient) Relay(ctx context.Context, opts ...grpc.CallOption) (RelayService_RelayClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RelayService_ServiceDesc.Streams[0], RelayService_Relay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayServiceRelayClient{ClientStream: stream}
+	return x, nil
+}
+
+type RelayService_RelayClient interface {
+	Send(*RoundMessage) error
+	Recv() (*RoundMessage, error)
+	grpc.ClientStream
+}
+
+type relayServiceRelayClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayServiceRelayClient) Send(m *RoundMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *relayServiceRelayClient) Recv() (*RoundMessage, error) {
+	m := new(RoundMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RelayServiceServer is the server API for RelayService service.
+// All implementations must embed UnimplementedRelayServiceServer
+// for forward compatibility
+//
+// RelayService는 게이트웨이를 거쳐 파티 사이의 라운드 메시지를 중계합니다.
+// 파티는 "party" 메타데이터에 자신의 이름을 담아 스트림을 엽니다.
+type RelayServiceServer interface {
+	Relay(RelayService_RelayServer) error
+	mustEmbedUnimplementedRelayServiceServer()
+}
+
+// UnimplementedRelayServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRelayServiceServer struct {
+}
+
+func (UnimplementedRelayServiceServer) Relay(RelayService_RelayServer) error {
+	return status.Errorf(codes.Unimplemented, "method Relay not implemented")
+}
+func (UnimplementedRelayServiceServer) mustEmbedUnimplementedRelayServiceServer() {}
+
+// UnsafeRelayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelayServiceServer will
+// result in compilation errors.
+type UnsafeRelayServiceServer interface {
+	mustEmbedUnimplementedRelayServiceServer()
+}
+
+func RegisterRelayServiceServer(s grpc.ServiceRegistrar, srv RelayServiceServer) {
+	s.RegisterService(&RelayService_ServiceDesc, srv)
+}
+
+func _RelayService_Relay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RelayServiceServer).Relay(&relayServiceRelayServer{ServerStream: stream})
+}
+
+type RelayService_RelayServer interface {
+	Send(*RoundMessage) error
+	Recv() (*RoundMessage, error)
+	grpc.ServerStream
+}
+
+type relayServiceRelayServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayServiceRelayServer) Send(m *RoundMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *relayServiceRelayServer) Recv() (*RoundMessage, error) {
+	m := new(RoundMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RelayService_ServiceDesc is the grpc.ServiceDesc for RelayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tss.v1.RelayService",
+	HandlerType: (*RelayServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Relay",
+			Handler:       _RelayService_Relay_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "tss/v1/tss.proto",
+}